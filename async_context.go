@@ -0,0 +1,7 @@
+package napi
+
+import (
+	"unsafe"
+)
+
+type AsyncContext unsafe.Pointer
@@ -1,6 +1,7 @@
 package napi
 
 import (
+	"fmt"
 	"unsafe"
 )
 
@@ -9,6 +10,26 @@ type AsyncWork struct {
 	ID     NapiGoAsyncWorkID
 }
 
-type AsyncExecuteCallback func(env Env)
+// AsyncExecuteCallback runs on a worker thread and must not call into JS. Its
+// error, or a PanicError if it panics, is passed to the complete callback.
+type AsyncExecuteCallback func(env Env) error
 
-type AsyncCompleteCallback func(env Env, status Status)
+type AsyncCompleteCallback func(env Env, status Status, err error)
+
+// PanicError is the error of an async execute callback that panicked.
+type PanicError struct {
+	Value any
+}
+
+var _ error = PanicError{}
+
+func (err PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", err.Value)
+}
+
+func (err PanicError) Unwrap() error {
+	if err, ok := err.Value.(error); ok {
+		return err
+	}
+	return nil
+}
@@ -1,3 +1,47 @@
 package napi
 
+/*
+#include <node/node_api.h>
+
+#define NAPI_GO_MAX_STACK_ARGS 8
+
+extern napi_value ExecuteCallback(
+	napi_env env,
+	napi_callback_info info,
+	napi_status status,
+	size_t argc,
+	napi_value *argv,
+	napi_value this_arg,
+	void *data
+);
+
+// napi_go_execute_callback is the napi_callback of every Go callback. It
+// retrieves the arguments, receiver and data of the call with a single
+// napi_get_cb_info call, into an array on its stack that stays valid until
+// ExecuteCallback returns.
+napi_value napi_go_execute_callback(
+	napi_env env,
+	napi_callback_info info
+) {
+	napi_value argv[NAPI_GO_MAX_STACK_ARGS];
+	size_t argc = NAPI_GO_MAX_STACK_ARGS;
+	napi_value this_arg = NULL;
+	void *data = NULL;
+	napi_status status = napi_get_cb_info(
+		env,
+		info,
+		&argc,
+		argv,
+		&this_arg,
+		&data
+	);
+	return ExecuteCallback(env, info, status, argc, argv, this_arg, data);
+}
+*/
+import "C"
+
+// maxStackArgs is the number of arguments napi_go_execute_callback retrieves
+// on its stack. Calls with more arguments need a second napi_get_cb_info call.
+const maxStackArgs = C.NAPI_GO_MAX_STACK_ARGS
+
 type Callback func(env Env, info CallbackInfo) Value
@@ -4,4 +4,14 @@ import (
 	"unsafe"
 )
 
-type CallbackInfo unsafe.Pointer
+// CallbackInfo describes a call from JS to a Callback. ExecuteCallback
+// retrieves it with a single napi_get_cb_info call before the callback runs.
+type CallbackInfo struct {
+	// This is the receiver of the call.
+	This Value
+	// Args holds the arguments of the call. Like the values in it, it is only
+	// valid until the callback returns, so callbacks must copy it to keep it.
+	Args []Value
+
+	info unsafe.Pointer // napi_callback_info
+}
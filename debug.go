@@ -0,0 +1,217 @@
+package napi
+
+/*
+#include <pthread.h>
+*/
+import "C"
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+// The hooks in this file validate how the bindings are used when the package
+// is built with the napidebug tag. In normal builds debug is false and the
+// hooks compile to nothing.
+//
+// Every binding taking an env calls debugCall first, which checks that env
+// was initialized by InitializeInstanceData, that the call happens on the JS
+// thread of env, and that no argument was created in a handle scope that has
+// already been closed. Bindings returning values report them to debugResult,
+// which tags them with the innermost open scope. The implicit scopes of
+// callbacks from JS are tracked like explicit handle scopes.
+
+// debugEnv is the debug state of an env. Apart from thread, it is only
+// accessed on the JS thread of the env.
+type debugEnv struct {
+	thread C.pthread_t
+	// scopes holds the generations of the open scopes, innermost last.
+	scopes []uint64
+	// values maps the values created in a scope to its generation. Values of
+	// closed scopes are kept, and are only forgotten once V8 reuses their
+	// handle for a new value, which bounds the size of the map.
+	values  map[Value]uint64
+	nextGen uint64
+}
+
+var debugEnvs sync.Map // Env -> *debugEnv
+
+// debugRegisterEnv records the calling thread as the JS thread of env, and
+// registers a cleanup hook that forgets env again when it is torn down.
+func debugRegisterEnv(env Env) {
+	if !debug {
+		return
+	}
+
+	debugEnvs.Store(env, &debugEnv{
+		thread: C.pthread_self(),
+		values: map[Value]uint64{},
+	})
+
+	// the hook is registered before any other of env, so it runs last
+	_, st := AddEnvCleanupHook(env, func() {
+		debugEnvs.Delete(env)
+	})
+	if st != StatusOK {
+		debugPanic("InitializeInstanceData", fmt.Sprintf(
+			"cannot register the cleanup hook of env %p: %s", env, st,
+		))
+	}
+}
+
+// debugCall validates a call of the binding fn with env and the Value
+// arguments args.
+func debugCall(env Env, fn string, args ...Value) {
+	if !debug {
+		return
+	}
+
+	d := debugLookup(env, fn)
+	for i, arg := range args {
+		d.checkValue(fn, fmt.Sprintf("argument %d", i+1), arg)
+	}
+}
+
+// debugArgs validates the argv argument of the binding fn, which calls a JS
+// function. It must follow a debugCall.
+func debugArgs(env Env, fn string, argv []Value) {
+	if !debug {
+		return
+	}
+
+	d := debugLookup(env, fn)
+	for i, arg := range argv {
+		d.checkValue(fn, fmt.Sprintf("argv[%d]", i), arg)
+	}
+}
+
+// debugResult tags value with the innermost open scope of env.
+func debugResult(env Env, value Value) {
+	if !debug {
+		return
+	}
+
+	debugLookup(env, "").record(value, 0)
+}
+
+// debugEscape tags value, which was escaped from the innermost open scope,
+// with the scope around it.
+func debugEscape(env Env, value Value) {
+	if !debug {
+		return
+	}
+
+	debugLookup(env, "").record(value, 1)
+}
+
+// debugOpenScope opens a new scope generation for the values created next.
+func debugOpenScope(env Env) {
+	if !debug {
+		return
+	}
+
+	d := debugLookup(env, "")
+	d.nextGen++
+	d.scopes = append(d.scopes, d.nextGen)
+}
+
+// debugCloseScope closes the innermost scope generation, which makes the
+// values created in it stale.
+func debugCloseScope(env Env) {
+	if !debug {
+		return
+	}
+
+	d := debugLookup(env, "")
+	if len(d.scopes) > 0 {
+		d.scopes = d.scopes[:len(d.scopes)-1]
+	}
+}
+
+func debugLookup(env Env, fn string) *debugEnv {
+	if env == nil {
+		debugPanic(fn, "called with a nil env")
+	}
+
+	v, ok := debugEnvs.Load(env)
+	if !ok {
+		debugPanic(fn, fmt.Sprintf(
+			"env %p was not initialized by InitializeInstanceData", env,
+		))
+	}
+
+	d := v.(*debugEnv)
+	if C.pthread_equal(d.thread, C.pthread_self()) == 0 {
+		debugPanic(fn, fmt.Sprintf(
+			"called off the JS thread of env %p; use a ThreadsafeFunction to call into JS from other goroutines",
+			env,
+		))
+	}
+	return d
+}
+
+func (d *debugEnv) checkValue(fn, name string, value Value) {
+	if value == nil {
+		return
+	}
+
+	gen, ok := d.values[value]
+	if ok && !d.isOpen(gen) {
+		debugPanic(fn, fmt.Sprintf(
+			"%s (%p) was created in a handle scope that has been closed",
+			name, value,
+		))
+	}
+}
+
+func (d *debugEnv) isOpen(gen uint64) bool {
+	for i := len(d.scopes) - 1; i >= 0; i-- {
+		if d.scopes[i] == gen {
+			return true
+		}
+	}
+	return false
+}
+
+// record tags value with the scope skip levels outside of the innermost one.
+// Values outside of any tracked scope are not tagged, since the scope they
+// belong to is unknown.
+func (d *debugEnv) record(value Value, skip int) {
+	if value == nil {
+		return
+	}
+
+	i := len(d.scopes) - 1 - skip
+	if i < 0 {
+		delete(d.values, value)
+		return
+	}
+	d.values[value] = d.scopes[i]
+}
+
+// DebugError is the panic value of a misuse detected in napidebug builds.
+type DebugError struct {
+	// Func is the name of the binding that detected the misuse, if any.
+	Func    string
+	Message string
+	// Stack is the Go stack of the goroutine that made the call.
+	Stack string
+}
+
+func (err *DebugError) Error() string {
+	if err.Func == "" {
+		return fmt.Sprintf("napidebug: %s\n\n%s", err.Message, err.Stack)
+	}
+	return fmt.Sprintf("napidebug: napi.%s: %s\n\n%s", err.Func, err.Message, err.Stack)
+}
+
+func debugPanic(fn, msg string) {
+	buf := make([]byte, maxStackTraceSize)
+	buf = buf[:runtime.Stack(buf, false)]
+	panic(&DebugError{
+		Func:    fn,
+		Message: msg,
+		Stack:   string(buf),
+	})
+}
@@ -0,0 +1,7 @@
+//go:build !napidebug
+
+package napi
+
+// debug enables the validation hooks of debug.go. Build with the napidebug
+// tag to enable them.
+const debug = false
@@ -0,0 +1,6 @@
+//go:build napidebug
+
+package napi
+
+// debug enables the validation hooks of debug.go.
+const debug = true
@@ -1,15 +1,19 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/abhisekp/napi-go"
 	"github.com/abhisekp/napi-go/entry"
+	"github.com/abhisekp/napi-go/js"
 )
 
 func init() {
 	entry.Export("getPromise", GetPromiseHandler)
+	entry.Export("getFailure", GetFailureHandler)
+	entry.Export("runAsync", js.AsCallback(RunAsync))
 }
 
 func GetPromiseHandler(env napi.Env, info napi.CallbackInfo) napi.Value {
@@ -23,12 +27,13 @@ func GetPromiseHandler(env napi.Env, info napi.CallbackInfo) napi.Value {
 	asyncWork, _ = napi.CreateAsyncWork(
 		env,
 		nil, asyncResourceName,
-		func(env napi.Env) {
+		func(env napi.Env) error {
 			fmt.Printf("AsyncExecuteCallback(start)\n")
 			defer fmt.Printf("AsyncExecuteCallback(stop)\n")
 			time.Sleep(time.Second)
+			return nil
 		},
-		func(env napi.Env, status napi.Status) {
+		func(env napi.Env, status napi.Status, err error) {
 			defer napi.DeleteAsyncWork(env, asyncWork)
 
 			if status == napi.StatusCancelled {
@@ -36,6 +41,14 @@ func GetPromiseHandler(env napi.Env, info napi.CallbackInfo) napi.Value {
 				return
 			}
 
+			if err != nil {
+				fmt.Printf("AsyncCompleteCallback(error)\n")
+				msg, _ := napi.CreateStringUtf8(env, err.Error())
+				rejection, _ := napi.CreateError(env, nil, msg)
+				napi.RejectDeferred(env, result.Deferred, rejection)
+				return
+			}
+
 			fmt.Printf("AsyncCompleteCallback\n")
 			resolution, _ := napi.CreateStringUtf8(env, "resolved")
 			napi.ResolveDeferred(env, result.Deferred, resolution)
@@ -46,4 +59,55 @@ func GetPromiseHandler(env napi.Env, info napi.CallbackInfo) napi.Value {
 	return result.Value
 }
 
+// GetFailureHandler returns a promise rejected with the error of an async
+// execute callback, which returns an error or panics on the worker thread
+// depending on whether its argument is "error" or "panic".
+func GetFailureHandler(env napi.Env, info napi.CallbackInfo) napi.Value {
+	mode, _ := napi.GetValueStringUtf8(env, info.Args[0])
+	result, _ := napi.CreatePromise(env)
+	asyncResourceName, _ := napi.CreateStringUtf8(
+		env,
+		"napi-go/async-failure-example",
+	)
+
+	var asyncWork napi.AsyncWork
+	asyncWork, _ = napi.CreateAsyncWork(
+		env,
+		nil, asyncResourceName,
+		func(env napi.Env) error {
+			if mode == "panic" {
+				panic("exploded")
+			}
+			return errors.New("failed")
+		},
+		func(env napi.Env, status napi.Status, err error) {
+			defer napi.DeleteAsyncWork(env, asyncWork)
+
+			msg, _ := napi.CreateStringUtf8(env, fmt.Sprint(err))
+			rejection, _ := napi.CreateError(env, nil, msg)
+			napi.RejectDeferred(env, result.Deferred, rejection)
+		},
+	)
+	napi.QueueAsyncWork(env, asyncWork)
+
+	return result.Value
+}
+
+// RunAsync returns a promise of js.Async that resolves, or rejects with an
+// error or a panic, depending on whether its argument is "ok", "error" or
+// "panic".
+func RunAsync(env js.Env, this js.Value, args []js.Value) any {
+	mode := args[0].String()
+	return env.Async("napi-go/run-async-example", func() (any, error) {
+		switch mode {
+		case "ok":
+			return "done", nil
+		case "panic":
+			panic(errors.New("exploded"))
+		default:
+			return nil, errors.New("failed")
+		}
+	})
+}
+
 func main() {}
@@ -0,0 +1,34 @@
+// A node-addon-api addon with the exports of the bench example, to compare
+// the cost of calls into napi-go with the usual C++ wrapper of Node-API.
+//
+// Build it with npm install in this directory, then pass
+// build/Release/baseline.node to bench.js as the baseline, or run the Go
+// benchmarks of the bench example, which pick it up from there.
+#include <napi.h>
+
+// Noop mirrors Noop, which returns without touching its arguments.
+static Napi::Value Noop(const Napi::CallbackInfo& info) {
+  return info.Env().Undefined();
+}
+
+// Add mirrors Add, which reads two doubles and returns their sum.
+static Napi::Value Add(const Napi::CallbackInfo& info) {
+  double a = info[0].As<Napi::Number>().DoubleValue();
+  double b = info[1].As<Napi::Number>().DoubleValue();
+  return Napi::Number::New(info.Env(), a + b);
+}
+
+// AddJS mirrors AddJS, which does what Add does through the js package.
+// node-addon-api has no higher level, so it is the same as Add.
+static Napi::Value AddJS(const Napi::CallbackInfo& info) {
+  return Add(info);
+}
+
+static Napi::Object Init(Napi::Env env, Napi::Object exports) {
+  exports.Set("noop", Napi::Function::New(env, Noop, "noop"));
+  exports.Set("add", Napi::Function::New(env, Add, "add"));
+  exports.Set("addJS", Napi::Function::New(env, AddJS, "addJS"));
+  return exports;
+}
+
+NODE_API_MODULE(baseline, Init)
@@ -0,0 +1,12 @@
+{
+  "targets": [
+    {
+      "target_name": "baseline",
+      "sources": ["addon.cc"],
+      "include_dirs": [
+        "<!(node -p \"require('node-addon-api').include_dir\")"
+      ],
+      "defines": ["NAPI_DISABLE_CPP_EXCEPTIONS", "NAPI_VERSION=8"]
+    }
+  ]
+}
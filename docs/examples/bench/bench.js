@@ -0,0 +1,70 @@
+// Measures the cost of calling into Go through napi-go.
+//
+// Usage: node docs/examples/bench/bench.js build/bench.node [baseline.node]
+//
+// A baseline addon, such as the node-addon-api one in baseline/, may be given
+// as the second argument. It must export noop(), add(a, b) and addJS(a, b).
+//
+// The valueOf benchmarks convert payloads of a given number of JS values with
+// js.ValueOf, property by property ("props") and through JSON ("json"), and
+// report the time per value. js.JSONThreshold is derived from them.
+//
+// BENCH_ITERATIONS sets the number of timed calls, 1e6 by default, and
+// BENCH_EXPORT restricts the run to a single benchmark, such as add or
+// valueOf/records/1024/json. bench_test.go uses both to report the results
+// as Go benchmarks.
+"use strict";
+
+const path = require("node:path");
+
+const [addonPath, baselinePath] = process.argv.slice(2);
+if (!addonPath) {
+  console.error("usage: bench.js <addon.node> [baseline.node]");
+  process.exit(2);
+}
+
+const iterations = Number(process.env.BENCH_ITERATIONS) || 1e6;
+const only = process.env.BENCH_EXPORT;
+
+function measure(name, fn, n = iterations, values = 1) {
+  // warm up before timing
+  for (let i = 0; i < Math.max(n / 10, 10); i++) fn(i, 1);
+
+  const start = process.hrtime.bigint();
+  for (let i = 0; i < n; i++) fn(i, 1);
+  const ns = Number(process.hrtime.bigint() - start) / n;
+
+  const perValue = values > 1 ? ` ${(ns / values).toFixed(1).padStart(8)} ns/value` : "";
+  console.log(`${name.padEnd(40)} ${ns.toFixed(1).padStart(10)} ns/op${perValue}`);
+}
+
+function run(label, addon) {
+  for (const name of ["noop", "add", "addJS"]) {
+    if (!only || only === name) measure(`${label} ${name}`, addon[name]);
+  }
+}
+
+const valueOfSizes = [16, 64, 256, 1024, 16384];
+
+function runValueOf(label, addon) {
+  for (const shape of ["numbers", "strings", "records"]) {
+    for (const size of valueOfSizes) {
+      for (const mode of ["props", "json"]) {
+        const name = `valueOf/${shape}/${size}/${mode}`;
+        if (only ? only !== name : !addon.valueOf) continue;
+
+        // keep the number of converted values, not calls, about constant
+        const n = only ? iterations : Math.max(Math.round(iterations / size), 100);
+        measure(`${label} ${name}`, () => addon.valueOf(shape, size, mode === "json"), n, size);
+      }
+    }
+  }
+}
+
+if (!only) measure("js add", (a, b) => a + b);
+const addon = require(path.resolve(addonPath));
+run("napi-go", addon);
+runValueOf("napi-go", addon);
+if (baselinePath) {
+  run("baseline", require(path.resolve(baselinePath)));
+}
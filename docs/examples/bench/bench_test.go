@@ -0,0 +1,155 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// The benchmarks below build this addon and time its exports in Node with
+// bench.js, reporting the time of a call as measured by Node in ns/call. The
+// ns/op reported by Go also includes starting Node. The node-addon-api
+// baseline is timed as well once built with npm install in baseline/, or
+// when NAPI_GO_BASELINE names another baseline addon.
+
+func BenchmarkNoop(b *testing.B) {
+	benchmarkExport(b, "noop")
+}
+
+func BenchmarkAdd(b *testing.B) {
+	benchmarkExport(b, "add")
+}
+
+func BenchmarkAddJS(b *testing.B) {
+	benchmarkExport(b, "addJS")
+}
+
+// BenchmarkValueOf compares converting payloads with js.ValueOf property by
+// property and through JSON. Its results are the basis of js.DefaultJSONThreshold.
+func BenchmarkValueOf(b *testing.B) {
+	path := addon(b)
+	for _, shape := range []string{"numbers", "strings", "records"} {
+		for _, size := range []int{16, 64, 256, 1024, 16384} {
+			for _, mode := range []string{"props", "json"} {
+				name := fmt.Sprintf("valueOf/%s/%d/%s", shape, size, mode)
+				b.Run(strings.TrimPrefix(name, "valueOf/"), func(b *testing.B) {
+					runBench(b, name, "napi-go", path)
+				})
+			}
+		}
+	}
+}
+
+var (
+	buildOnce sync.Once
+	buildDir  string
+	buildErr  error
+)
+
+func TestMain(m *testing.M) {
+	code := m.Run()
+	if buildDir != "" {
+		os.RemoveAll(buildDir)
+	}
+	os.Exit(code)
+}
+
+// addon builds this package as an addon once per test binary.
+func addon(b *testing.B) string {
+	if _, err := exec.LookPath("node"); err != nil {
+		b.Skip("node is not installed")
+	}
+
+	buildOnce.Do(func() {
+		buildDir, buildErr = os.MkdirTemp("", "napi-go-bench")
+		if buildErr != nil {
+			return
+		}
+
+		cmd := exec.Command(
+			"go", "build", "-buildmode=c-shared",
+			"-o", filepath.Join(buildDir, "bench.node"), ".",
+		)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			buildErr = fmt.Errorf("%v\n%s", err, out)
+		}
+	})
+	if buildErr != nil {
+		b.Skipf("cannot build the addon: %v", buildErr)
+	}
+	return filepath.Join(buildDir, "bench.node")
+}
+
+// baseline returns the path of the baseline addon, or "" if it is not built.
+func baseline() string {
+	path := os.Getenv("NAPI_GO_BASELINE")
+	if path == "" {
+		path = filepath.Join("baseline", "build", "Release", "baseline.node")
+	}
+	if _, err := os.Stat(path); err != nil {
+		return ""
+	}
+	return path
+}
+
+func benchmarkExport(b *testing.B, name string) {
+	path := addon(b)
+	b.Run("napi-go", func(b *testing.B) {
+		runBench(b, name, "napi-go", path)
+	})
+	b.Run("baseline", func(b *testing.B) {
+		base := baseline()
+		if base == "" {
+			b.Skip("the baseline addon is not built")
+		}
+		runBench(b, name, "baseline", path, base)
+	})
+}
+
+// runBench runs bench.js for the benchmark name with b.N iterations and
+// reports the time per call of the addon labeled label.
+func runBench(b *testing.B, name, label string, args ...string) {
+	cmd := exec.Command("node", append([]string{"bench.js"}, args...)...)
+	cmd.Env = append(
+		os.Environ(),
+		"BENCH_ITERATIONS="+strconv.Itoa(b.N),
+		"BENCH_EXPORT="+name,
+	)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		b.Fatalf("bench.js: %v\n%s", err, out)
+	}
+
+	prefix := label + " " + name + " "
+	s := bufio.NewScanner(bytes.NewReader(out))
+	for s.Scan() {
+		line := s.Text()
+		if !strings.HasPrefix(line, prefix) {
+			continue
+		}
+
+		// the times are followed by their units, ns/op for a call and
+		// ns/value for the values converted by the valueOf benchmarks
+		fields := strings.Fields(line)
+		for i := 1; i < len(fields); i++ {
+			unit := map[string]string{"ns/op": "ns/call", "ns/value": "ns/value"}[fields[i]]
+			if unit == "" {
+				continue
+			}
+			ns, err := strconv.ParseFloat(fields[i-1], 64)
+			if err != nil {
+				b.Fatalf("bench.js: cannot parse %q", line)
+			}
+			b.ReportMetric(ns, unit)
+		}
+		return
+	}
+	b.Fatalf("bench.js: no result for %s\n%s", prefix, out)
+}
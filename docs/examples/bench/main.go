@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/abhisekp/napi-go"
+	"github.com/abhisekp/napi-go/entry"
+	"github.com/abhisekp/napi-go/js"
+)
+
+func init() {
+	entry.Export("noop", Noop)
+	entry.Export("add", Add)
+	entry.Export("addJS", js.AsCallback(AddJS))
+	entry.Export("valueOf", js.AsCallback(ValueOf))
+}
+
+func Noop(env napi.Env, info napi.CallbackInfo) napi.Value {
+	return nil
+}
+
+func Add(env napi.Env, info napi.CallbackInfo) napi.Value {
+	a, _ := napi.GetValueDouble(env, info.Args[0])
+	b, _ := napi.GetValueDouble(env, info.Args[1])
+	result, _ := napi.CreateDouble(env, a+b)
+	return result
+}
+
+func AddJS(env js.Env, this js.Value, args []js.Value) any {
+	return args[0].Float() + args[1].Float()
+}
+
+type payloadKey struct {
+	shape string
+	size  int
+}
+
+var payloads = map[payloadKey][]any{}
+
+// payload returns a payload of about size JS values. numbers is an array of
+// numbers, and records an array of objects with three properties, each of
+// which costs seven values including the property keys.
+func payload(shape string, size int) []any {
+	key := payloadKey{shape, size}
+	if p, ok := payloads[key]; ok {
+		return p
+	}
+
+	var p []any
+	switch shape {
+	case "numbers":
+		p = make([]any, size)
+		for i := range p {
+			p[i] = float64(i)
+		}
+	case "strings":
+		p = make([]any, size)
+		for i := range p {
+			p[i] = fmt.Sprint("string ", i)
+		}
+	case "records":
+		p = make([]any, size/7)
+		for i := range p {
+			p[i] = map[string]any{
+				"id":   float64(i),
+				"name": fmt.Sprint("record ", i),
+				"ok":   i%2 == 0,
+			}
+		}
+	default:
+		panic(fmt.Errorf("unknown payload shape %q", shape))
+	}
+
+	payloads[key] = p
+	return p
+}
+
+// ValueOf converts a payload with ValueOf, through JSON if its third
+// argument is true and property by property otherwise.
+func ValueOf(env js.Env, this js.Value, args []js.Value) any {
+	p := payload(args[0].String(), args[1].Int())
+
+	threshold := js.JSONThreshold()
+	defer js.SetJSONThreshold(threshold)
+	if args[2].Bool() {
+		js.SetJSONThreshold(1)
+	} else {
+		js.SetJSONThreshold(0)
+	}
+	return env.ValueOf(p)
+}
+
+func main() {}
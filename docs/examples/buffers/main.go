@@ -0,0 +1,60 @@
+package main
+
+import (
+	"github.com/abhisekp/napi-go"
+	"github.com/abhisekp/napi-go/entry"
+	"github.com/abhisekp/napi-go/js"
+)
+
+func init() {
+	entry.Export("external", js.AsCallback(External))
+	entry.Export("released", js.AsCallback(Released))
+	entry.Export("bufferFrom", js.AsCallback(BufferFrom))
+	entry.Export("features", js.AsCallback(Features))
+}
+
+var released int
+
+// External returns an external ArrayBuffer of the size given as argument,
+// holding the bytes 0, 1, 2... It counts in Released once it is collected.
+func External(env js.Env, this js.Value, args []js.Value) any {
+	data := make([]byte, args[0].Int())
+	for i := range data {
+		data[i] = byte(i)
+	}
+	return env.ExternalArrayBuffer(data, func() {
+		released++
+	})
+}
+
+// Released returns the number of buffers of External that were released.
+func Released(env js.Env, this js.Value, args []js.Value) any {
+	return released
+}
+
+// BufferFrom returns a Buffer sharing the ArrayBuffer, offset and length
+// given as arguments.
+func BufferFrom(env js.Env, this js.Value, args []js.Value) any {
+	return env.BufferFrom(args[0], args[1].Int(), args[2].Int())
+}
+
+// Features returns the Node-API version and the optional features of the
+// running Node release.
+func Features(env js.Env, this js.Value, args []js.Value) any {
+	version, st := napi.GetVersion(env.Env)
+	if st != napi.StatusOK {
+		panic(napi.NewError(env.Env, "GetVersion", st))
+	}
+
+	return map[string]any{
+		"version":               version,
+		"symbolFor":             env.Supports(js.FeatureSymbolFor),
+		"propertyKeys":          env.Supports(js.FeaturePropertyKeys),
+		"externalStrings":       env.Supports(js.FeatureExternalStrings),
+		"bufferFromArrayBuffer": env.Supports(js.FeatureBufferFromArrayBuffer),
+		"postFinalizer":         env.Supports(js.FeaturePostFinalizer),
+		"unknown":               env.Supports(js.Feature(-1)),
+	}
+}
+
+func main() {}
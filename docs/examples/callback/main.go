@@ -1,12 +1,19 @@
 package main
 
 import (
+	"runtime"
+	"sync/atomic"
+
 	"github.com/abhisekp/napi-go"
 	"github.com/abhisekp/napi-go/entry"
 )
 
 func init() {
 	entry.Export("getCallback", GetCallbackHandler)
+	entry.Export("getCallbacks", GetCallbacksHandler)
+	entry.Export("getWorks", GetWorksHandler)
+	entry.Export("failWorks", FailWorksHandler)
+	entry.Export("getReleased", GetReleasedHandler)
 }
 
 func GetCallbackHandler(env napi.Env, info napi.CallbackInfo) napi.Value {
@@ -22,4 +29,104 @@ func GetCallbackHandler(env napi.Env, info napi.CallbackInfo) napi.Value {
 	return result
 }
 
+// released counts the payloads of the callbacks and async works created
+// below that the Go garbage collector has freed, which requires napi-go to
+// release the callbacks and works once JS is done with them.
+var released atomic.Int32
+
+func newPayload(i int32) *int32 {
+	payload := &i
+	runtime.SetFinalizer(payload, func(*int32) {
+		released.Add(1)
+	})
+	return payload
+}
+
+// GetCallbacksHandler returns an array of as many functions as its argument,
+// each returning its index.
+func GetCallbacksHandler(env napi.Env, info napi.CallbackInfo) napi.Value {
+	n, _ := napi.GetValueInt32(env, info.Args[0])
+	result, _ := napi.CreateArrayWithLength(env, int(n))
+	for i := int32(0); i < n; i++ {
+		payload := newPayload(i)
+		fn, _ := napi.CreateFunction(
+			env,
+			"",
+			func(env napi.Env, info napi.CallbackInfo) napi.Value {
+				result, _ := napi.CreateInt32(env, *payload)
+				return result
+			},
+		)
+		napi.SetElement(env, result, int(i), fn)
+	}
+
+	return result
+}
+
+// GetWorksHandler returns an array of as many promises as its argument, each
+// resolved with its index by an async work.
+func GetWorksHandler(env napi.Env, info napi.CallbackInfo) napi.Value {
+	n, _ := napi.GetValueInt32(env, info.Args[0])
+	name, _ := napi.CreateStringUtf8(env, "napi-go/callback-example")
+	result, _ := napi.CreateArrayWithLength(env, int(n))
+	for i := int32(0); i < n; i++ {
+		payload := newPayload(i)
+		promise, _ := napi.CreatePromise(env)
+
+		var work napi.AsyncWork
+		work, _ = napi.CreateAsyncWork(
+			env,
+			nil, name,
+			func(env napi.Env) error {
+				return nil
+			},
+			func(env napi.Env, status napi.Status, err error) {
+				defer napi.DeleteAsyncWork(env, work)
+				resolution, _ := napi.CreateInt32(env, *payload)
+				napi.ResolveDeferred(env, promise.Deferred, resolution)
+			},
+		)
+		napi.QueueAsyncWork(env, work)
+		napi.SetElement(env, result, int(i), promise.Value)
+	}
+
+	return result
+}
+
+// FailWorksHandler tries to create as many async works as its argument
+// without a resource name, which Node-API rejects, and returns the number of
+// attempts that failed.
+func FailWorksHandler(env napi.Env, info napi.CallbackInfo) napi.Value {
+	n, _ := napi.GetValueInt32(env, info.Args[0])
+	var failed int32
+	for i := int32(0); i < n; i++ {
+		payload := newPayload(i)
+		_, st := napi.CreateAsyncWork(
+			env,
+			nil, nil,
+			func(env napi.Env) error {
+				return nil
+			},
+			func(env napi.Env, status napi.Status, err error) {
+				_ = *payload
+			},
+		)
+		if st != napi.StatusOK {
+			failed++
+		}
+	}
+
+	result, _ := napi.CreateInt32(env, failed)
+	return result
+}
+
+// GetReleasedHandler runs the Go garbage collector and returns the number of
+// payloads it has freed so far. Finalizers run in the background, so the
+// count may lag behind.
+func GetReleasedHandler(env napi.Env, info napi.CallbackInfo) napi.Value {
+	runtime.GC()
+	result, _ := napi.CreateInt32(env, released.Load())
+	return result
+}
+
 func main() {}
@@ -0,0 +1,61 @@
+// Command debug misuses napi-go in ways that builds with the napidebug tag
+// detect. In other builds the misuses are undefined behavior, so its exports
+// must only be called from napidebug builds.
+package main
+
+import (
+	"github.com/abhisekp/napi-go"
+	"github.com/abhisekp/napi-go/entry"
+	"github.com/abhisekp/napi-go/js"
+)
+
+func init() {
+	entry.Export("offThread", js.AsCallback(OffThread))
+	entry.Export("outOfScope", js.AsCallback(OutOfScope))
+	entry.Export("keep", js.AsCallback(Keep))
+	entry.Export("useKept", js.AsCallback(UseKept))
+	entry.Export("nilEnv", js.AsCallback(NilEnv))
+}
+
+// OffThread returns the error of creating an object from another goroutine.
+func OffThread(env js.Env, this js.Value, args []js.Value) any {
+	result := make(chan any)
+	go func() {
+		defer func() {
+			result <- recover()
+		}()
+		napi.CreateObject(env.Env)
+	}()
+	return (<-result).(error).Error()
+}
+
+// OutOfScope uses a value after the handle scope it was created in closed.
+func OutOfScope(env js.Env, this js.Value, args []js.Value) any {
+	var stale js.Value
+	env.Scope(func(env js.Env) {
+		stale = env.ValueOf("stale")
+	})
+	return stale.Type()
+}
+
+var kept napi.Value
+
+// Keep keeps its argument for UseKept, without a reference.
+func Keep(env js.Env, this js.Value, args []js.Value) any {
+	kept = args[0].Value
+	return nil
+}
+
+// UseKept uses the value of Keep after the call that received it returned.
+func UseKept(env js.Env, this js.Value, args []js.Value) any {
+	_, st := napi.Typeof(env.Env, kept)
+	return int(st)
+}
+
+// NilEnv calls a binding with a nil env.
+func NilEnv(env js.Env, this js.Value, args []js.Value) any {
+	napi.CreateObject(nil)
+	return nil
+}
+
+func main() {}
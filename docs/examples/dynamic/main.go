@@ -0,0 +1,67 @@
+package main
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/abhisekp/napi-go/entry"
+	"github.com/abhisekp/napi-go/js"
+)
+
+func init() {
+	entry.Export("newRecord", js.AsCallback(NewRecord))
+}
+
+// Record is a DynamicObject that keeps its properties in insertion order and
+// rejects properties starting with an underscore.
+type Record struct {
+	keys   []string
+	values map[string]any
+}
+
+func (r *Record) Get(key string) (any, bool) {
+	v, ok := r.values[key]
+	return v, ok
+}
+
+func (r *Record) Set(key string, value js.Value) bool {
+	if strings.HasPrefix(key, "_") {
+		return false
+	}
+
+	var v any
+	if err := value.Decode(&v); err != nil {
+		return false
+	}
+	if _, ok := r.values[key]; !ok {
+		r.keys = append(r.keys, key)
+	}
+	r.values[key] = v
+	return true
+}
+
+func (r *Record) Has(key string) bool {
+	_, ok := r.values[key]
+	return ok
+}
+
+func (r *Record) Keys() []string {
+	return r.keys
+}
+
+func (r *Record) Delete(key string) bool {
+	if i := slices.Index(r.keys, key); i >= 0 {
+		r.keys = slices.Delete(r.keys, i, i+1)
+		delete(r.values, key)
+	}
+	return true
+}
+
+func NewRecord(env js.Env, this js.Value, args []js.Value) any {
+	return env.NewDynamic(&Record{
+		keys:   []string{"x", "y"},
+		values: map[string]any{"x": 1.0, "y": "two"},
+	})
+}
+
+func main() {}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"github.com/abhisekp/napi-go/entry"
+	"github.com/abhisekp/napi-go/js"
+)
+
+func init() {
+	entry.Export("remember", js.AsCallback(Remember))
+	entry.Export("recall", js.AsCallback(Recall))
+	entry.Export("forget", js.AsCallback(Forget))
+	entry.Export("recallFromGoroutine", js.AsCallback(RecallFromGoroutine))
+	entry.Export("sumInScopes", js.AsCallback(SumInScopes))
+	entry.Export("escape", js.AsCallback(Escape))
+	entry.Export("numbers", js.AsCallback(Numbers))
+}
+
+var remembered = map[string]*js.Ref{}
+
+// Remember keeps the value given second under the name given first, with a
+// weak reference if the third argument is true.
+func Remember(env js.Env, this js.Value, args []js.Value) any {
+	name := args[0].String()
+	if ref, ok := remembered[name]; ok {
+		ref.Delete()
+	}
+
+	if len(args) > 2 && args[2].Bool() {
+		remembered[name] = env.NewWeakRef(args[1])
+	} else {
+		remembered[name] = env.NewRef(args[1])
+	}
+	return nil
+}
+
+// Recall returns the value remembered under the name given as argument.
+func Recall(env js.Env, this js.Value, args []js.Value) any {
+	return remembered[args[0].String()].Value()
+}
+
+// Forget deletes the reference to the value remembered under the name given
+// as argument, twice to show that deleting again does nothing.
+func Forget(env js.Env, this js.Value, args []js.Value) any {
+	ref := remembered[args[0].String()]
+	ref.Delete()
+	ref.Delete()
+	return nil
+}
+
+// RecallFromGoroutine returns the error of accessing the value remembered
+// under the name given as argument from another goroutine.
+func RecallFromGoroutine(env js.Env, this js.Value, args []js.Value) any {
+	ref := remembered[args[0].String()]
+	result := make(chan any)
+	go func() {
+		defer func() {
+			result <- recover()
+		}()
+		ref.Value()
+	}()
+	return (<-result).(error).Error()
+}
+
+// SumInScopes returns the sum of 0 to n-1, for the n given as argument, with
+// the values of each step created and released in a handle scope of its own.
+func SumInScopes(env js.Env, this js.Value, args []js.Value) any {
+	total := 0.0
+	for i, n := 0, args[0].Int(); i < n; i++ {
+		env.Scope(func(env js.Env) {
+			step := env.ValueOf(map[string]any{"i": i})
+			total += step.Get("i").Float()
+		})
+	}
+	return total
+}
+
+// Escape returns an object holding its argument, created in a handle scope
+// that has been closed since.
+func Escape(env js.Env, this js.Value, args []js.Value) any {
+	s := args[0].String()
+	return env.EscapableScope(func(env js.Env) js.Value {
+		return env.ValueOf(map[string]any{"s": s})
+	})
+}
+
+// Numbers returns an array of 0 to n-1, for the n given as argument. ValueOf
+// converts large slices in batches of handle scopes.
+func Numbers(env js.Env, this js.Value, args []js.Value) any {
+	numbers := make([]int, args[0].Int())
+	for i := range numbers {
+		numbers[i] = i
+	}
+	return numbers
+}
+
+func main() {}
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -15,6 +17,13 @@ func init() {
 	entry.Export("getArray", js.AsCallback(GetArray))
 	entry.Export("getPromiseResolve", js.AsCallback(GetPromiseResolve))
 	entry.Export("getPromiseReject", js.AsCallback(GetPromiseReject))
+	entry.Export("getCountdown", js.AsCallback(GetCountdown))
+	entry.Export("getTicker", js.AsCallback(GetTicker))
+	entry.Export("callLater", js.AsCallback(CallLater))
+	entry.Export("sleep", js.AsCallback(Sleep))
+	entry.Export("timeout", js.AsCallback(Timeout))
+	entry.Export("getNumbers", js.AsCallback(GetNumbers))
+	entry.Export("getFailing", js.AsCallback(GetFailing))
 }
 
 func GetMapHandler(env napi.Env, info napi.CallbackInfo) napi.Value {
@@ -78,4 +87,109 @@ func GetPromiseReject(env js.Env, this js.Value, args []js.Value) any {
 	return promise
 }
 
+func GetCountdown(env js.Env, this js.Value, args []js.Value) any {
+	ch := make(chan int)
+
+	go func() {
+		defer close(ch)
+		for i := 3; i > 0; i-- {
+			time.Sleep(100 * time.Millisecond)
+			ch <- i
+		}
+	}()
+
+	return ch
+}
+
+func GetTicker(env js.Env, this js.Value, args []js.Value) any {
+	emitter := env.NewEmitter()
+
+	go func() {
+		defer emitter.Close()
+		for i := 0; i < 3; i++ {
+			time.Sleep(100 * time.Millisecond)
+			emitter.Emit("tick", i)
+		}
+		emitter.Emit("done")
+	}()
+
+	return emitter
+}
+
+// CallLater calls its argument after a delay, in the async context of the
+// caller, so that AsyncLocalStorage stores remain visible to it.
+func CallLater(env js.Env, this js.Value, args []js.Value) any {
+	ctx := env.NewAsyncContext("napi-go/call-later")
+	fn := env.NewRef(args[0])
+	tsFn := env.NewThreadsafeFunc("napi-go/call-later", this)
+
+	go func() {
+		defer tsFn.Release()
+		time.Sleep(100 * time.Millisecond)
+		tsFn.Call(func(env js.Env, this js.Value) {
+			defer ctx.Destroy()
+			defer fn.Delete()
+			ctx.MakeCallback(fn.Value(), this)
+		})
+	}()
+
+	return nil
+}
+
+// Sleep returns a promise that resolves after the number of milliseconds
+// given first, or rejects with the reason of the AbortSignal given second.
+func Sleep(env js.Env, this js.Value, args []js.Value) any {
+	d := time.Duration(args[0].Int()) * time.Millisecond
+	ctx, cancel := args[1].AsContext(context.Background())
+
+	return env.Async("napi-go/sleep", func() (any, error) {
+		defer cancel()
+		select {
+		case <-time.After(d):
+			return "slept", nil
+		case <-ctx.Done():
+			return nil, context.Cause(ctx)
+		}
+	})
+}
+
+// Timeout returns an AbortSignal that aborts after the number of milliseconds
+// given as argument.
+func Timeout(env js.Env, this js.Value, args []js.Value) any {
+	ctx, cancel := context.WithCancelCause(context.Background())
+	time.AfterFunc(time.Duration(args[0].Int())*time.Millisecond, func() {
+		cancel(errors.New("timed out"))
+	})
+	return env.AbortSignalFor(ctx)
+}
+
+// GetNumbers returns an async iterator over a channel of 0, 1, 2... that
+// stops producing once nothing reads it for a second.
+func GetNumbers(env js.Env, this js.Value, args []js.Value) any {
+	ch := make(chan int)
+
+	go func() {
+		defer close(ch)
+		for i := 0; ; i++ {
+			select {
+			case ch <- i:
+			case <-time.After(time.Second):
+				return
+			}
+		}
+	}()
+
+	return ch
+}
+
+// GetFailing returns an async iterator whose producer yields its argument
+// and then panics.
+func GetFailing(env js.Env, this js.Value, args []js.Value) any {
+	first := args[0].String()
+	return js.Producer(func(ctx context.Context, yield func(value any) bool) error {
+		yield(first)
+		panic(errors.New("producer failed"))
+	})
+}
+
 func main() {}
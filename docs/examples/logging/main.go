@@ -0,0 +1,54 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"strings"
+
+	"github.com/abhisekp/napi-go"
+	"github.com/abhisekp/napi-go/entry"
+	"github.com/abhisekp/napi-go/js"
+)
+
+func init() {
+	entry.Export("captureLogs", js.AsCallback(CaptureLogs))
+	entry.Export("restoreLogs", js.AsCallback(RestoreLogs))
+	entry.Export("logs", js.AsCallback(Logs))
+	entry.Export("panics", js.AsCallback(Panics))
+}
+
+var logs bytes.Buffer
+
+// CaptureLogs makes napi-go log to Logs, in JSON, and report panics to
+// process.emitWarning.
+func CaptureLogs(env js.Env, this js.Value, args []js.Value) any {
+	napi.SetLogger(slog.New(slog.NewJSONHandler(&logs, nil)))
+	napi.SetEmitWarnings(true)
+	return nil
+}
+
+// RestoreLogs restores the default logging of napi-go.
+func RestoreLogs(env js.Env, this js.Value, args []js.Value) any {
+	napi.SetLogger(nil)
+	napi.SetEmitWarnings(false)
+	return nil
+}
+
+// Logs returns the records logged since the last call, one JSON document
+// each.
+func Logs(env js.Env, this js.Value, args []js.Value) any {
+	records := strings.Split(strings.TrimSpace(logs.String()), "\n")
+	logs.Reset()
+	if records[0] == "" {
+		return []string{}
+	}
+	return records
+}
+
+// Panics panics with an error of the message given as argument.
+func Panics(env js.Env, this js.Value, args []js.Value) any {
+	panic(errors.New(args[0].String()))
+}
+
+func main() {}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/abhisekp/napi-go"
+	"github.com/abhisekp/napi-go/entry"
+	"github.com/abhisekp/napi-go/js"
+)
+
+func init() {
+	entry.Export("newCounter", js.AsCallback(NewCounter))
+	entry.Export("newGreeter", js.AsCallback(NewGreeter))
+	entry.Export("newBroken", js.AsCallback(NewBroken))
+	entry.Export("counterValue", js.AsCallback(CounterValue))
+	entry.Export("newPoint", js.AsCallback(NewPoint))
+	entry.Export("pointX", js.AsCallback(PointX))
+	entry.Export("wrapTagged", js.AsCallback(WrapTagged))
+}
+
+// Counter exposes all of its exported methods to JS.
+type Counter struct {
+	N int
+}
+
+func (c *Counter) Add(n int) int {
+	c.N += n
+	return c.N
+}
+
+func (c *Counter) Sum(xs ...int) int {
+	for _, x := range xs {
+		c.N += x
+	}
+	return c.N
+}
+
+func (c *Counter) Describe(env js.Env, prefix string) js.Value {
+	return env.ValueOf(fmt.Sprintf("%s%d", prefix, c.N))
+}
+
+func (c *Counter) Split() (int, string) {
+	return c.N, fmt.Sprint(c.N)
+}
+
+func (c *Counter) Fail(msg string) error {
+	if msg == "" {
+		return nil
+	}
+	return errors.New(msg)
+}
+
+// Greeter exposes only the methods named by JSMethods.
+type Greeter struct {
+	Name string
+}
+
+func (g *Greeter) JSMethods() []string {
+	return []string{"Greet"}
+}
+
+func (g *Greeter) Greet() string {
+	return "hello " + g.Name
+}
+
+func (g *Greeter) Secret() string {
+	return strings.ToUpper(g.Name)
+}
+
+// Broken names a method it does not have.
+type Broken struct{}
+
+func (*Broken) JSMethods() []string {
+	return []string{"Missing"}
+}
+
+func NewCounter(env js.Env, this js.Value, args []js.Value) any {
+	return js.Methods(&Counter{})
+}
+
+func NewGreeter(env js.Env, this js.Value, args []js.Value) any {
+	return &Greeter{Name: args[0].String()}
+}
+
+func NewBroken(env js.Env, this js.Value, args []js.Value) any {
+	return &Broken{}
+}
+
+// CounterValue returns the count of a counter created by NewCounter.
+func CounterValue(env js.Env, this js.Value, args []js.Value) any {
+	c, err := js.Unwrap[Counter](args[0])
+	if err != nil {
+		return env.Undefined()
+	}
+	return c.N
+}
+
+type Point struct {
+	X, Y float64
+}
+
+// NewPoint returns a plain object wrapping a Point of the coordinates given
+// as arguments.
+func NewPoint(env js.Env, this js.Value, args []js.Value) any {
+	obj := env.ValueOf(map[string]any{})
+	js.Wrap(obj, &Point{X: args[0].Float(), Y: args[1].Float()})
+	return obj
+}
+
+// PointX returns the X coordinate of the Point wrapped by its argument.
+func PointX(env js.Env, this js.Value, args []js.Value) any {
+	p, err := js.Unwrap[Point](args[0])
+	if err != nil {
+		return env.Undefined()
+	}
+	return p.X
+}
+
+// WrapTagged tags its argument with a foreign type tag before wrapping a
+// Point in it, and returns the error of Wrap and whether the object was left
+// wrapped.
+func WrapTagged(env js.Env, this js.Value, args []js.Value) (result any) {
+	obj := args[0]
+	st := napi.TypeTagObject(env.Env, obj.Value, napi.TypeTag{Lower: 1, Upper: 2})
+	if st != napi.StatusOK {
+		panic(napi.NewError(env.Env, "TypeTagObject", st))
+	}
+
+	defer func() {
+		_, st := napi.Unwrap(env.Env, obj.Value)
+		result = []any{fmt.Sprint(recover()), st == napi.StatusOK}
+	}()
+	js.Wrap(obj, &Point{})
+	return nil
+}
+
+func main() {}
@@ -0,0 +1,511 @@
+// Loads every example addon from the build directory given as the first
+// argument and exercises its exports, exiting non-zero on failure.
+//
+// Usage: node docs/examples/smoke.js build
+"use strict";
+
+const assert = require("node:assert");
+const { AsyncLocalStorage } = require("node:async_hooks");
+const { once } = require("node:events");
+const path = require("node:path");
+const util = require("node:util");
+const { Writable } = require("node:stream");
+const { Worker } = require("node:worker_threads");
+
+const dir = path.resolve(process.argv[2] || "build");
+const load = (name) => require(path.join(dir, `${name}.node`));
+
+// collect runs the garbage collector until done returns true, letting
+// finalizers run in between, and gives up after a few attempts.
+const collect = async (done) => {
+  for (let i = 0; i < 10 && !done(); i++) {
+    globalThis.gc();
+    await new Promise((resolve) => setImmediate(resolve));
+  }
+};
+
+const examples = {
+  "hello-world": async (m) => {
+    m.hello();
+  },
+
+  buffers: async (m) => {
+    const bytes = new Uint8Array(m.external(300));
+    assert.strictEqual(bytes.length, 300);
+    assert.strictEqual(bytes[299], 299 % 256);
+
+    // empty buffers are copied, releasing the Go slice right away
+    assert.strictEqual(m.external(0).byteLength, 0);
+    assert.strictEqual(m.released(), 1);
+
+    const ab = new ArrayBuffer(8);
+    const buf = m.bufferFrom(ab, 2, 4);
+    assert.ok(Buffer.isBuffer(buf));
+    assert.strictEqual(buf.buffer, ab);
+    assert.strictEqual(buf.length, 4);
+    new Uint8Array(ab)[2] = 7;
+    assert.strictEqual(buf[0], 7);
+
+    const features = m.features();
+    const napiVersion = Number(process.versions.napi);
+    assert.strictEqual(features.version, napiVersion);
+    for (const name of ["symbolFor", "propertyKeys", "externalStrings", "bufferFromArrayBuffer", "postFinalizer"]) {
+      assert.strictEqual(typeof features[name], "boolean", name);
+    }
+    // stable features are available from the version that made them stable
+    if (napiVersion >= 9) assert.ok(features.symbolFor);
+    if (napiVersion >= 10) assert.ok(features.propertyKeys && features.externalStrings);
+    assert.strictEqual(features.unknown, false);
+
+    if (globalThis.gc) {
+      (() => {
+        for (let i = 0; i < 10; i++) m.external(1 << 20);
+      })();
+      await collect(() => m.released() >= 11);
+      assert.strictEqual(m.released(), 11);
+    }
+  },
+
+  callback: async (m) => {
+    assert.strictEqual(m.getCallback()(), "hello world");
+
+    // the data of callbacks and async works is passed to C as tokens, which
+    // the check-cgo target verifies, and released with them
+    (() => {
+      const callbacks = m.getCallbacks(1000);
+      assert.ok(callbacks.every((fn, i) => fn() === i));
+    })();
+    const works = await Promise.all(m.getWorks(100));
+    assert.ok(works.every((n, i) => n === i));
+    assert.strictEqual(m.failWorks(10), 10);
+    if (globalThis.gc) {
+      await collect(() => m.getReleased() === 1110);
+      assert.strictEqual(m.getReleased(), 1110);
+    }
+  },
+
+  debug: async (m) => {
+    // the misuses are only detected by napidebug builds, as check-debug runs
+    if (!process.env.NAPI_GO_DEBUG) return;
+
+    assert.match(m.offThread(), /^napidebug: napi\.CreateObject: called off the JS thread/);
+    assert.throws(() => m.outOfScope(), /napidebug: napi\.Typeof: argument 1 .* handle scope that has been closed/);
+    m.keep({});
+    assert.throws(() => m.useKept(), /napidebug: napi\.Typeof: argument 1 .* handle scope that has been closed/);
+    assert.throws(() => m.nilEnv(), /napidebug: napi\.CreateObject: called with a nil env/);
+
+    // envs of workers are forgotten again when they are torn down
+    const worker = new Worker(`require(${JSON.stringify(path.join(dir, "debug.node"))}).keep({});`, {
+      eval: true,
+    });
+    const [code] = await once(worker, "exit");
+    assert.strictEqual(code, 0);
+  },
+
+  "describe-args": async (m) => {
+    assert.deepStrictEqual(m.describeArgs(1, "a", null), [
+      "number",
+      "string",
+      "null",
+    ]);
+
+    // more arguments than napi-go retrieves on the stack
+    const many = [1, "a", null, undefined, true, {}, [], 2n, Symbol(), () => {}];
+    assert.deepStrictEqual(m.describeArgs(...many), [
+      "number",
+      "string",
+      "null",
+      "undefined",
+      "boolean",
+      "object",
+      "object",
+      "bigint",
+      "symbol",
+      "function",
+    ]);
+  },
+
+  "async-promise": async (m) => {
+    assert.strictEqual(await m.getPromise(), "resolved");
+
+    // failures on the worker thread reject the promise on the JS thread
+    await assert.rejects(m.getFailure("error"), { message: "failed" });
+    await assert.rejects(m.getFailure("panic"), { message: "panic: exploded" });
+    assert.strictEqual(await m.runAsync("ok"), "done");
+    await assert.rejects(m.runAsync("error"), { message: "failed" });
+    await assert.rejects(m.runAsync("panic"), { message: "panic: exploded" });
+  },
+
+  dynamic: async (m) => {
+    const d = m.newRecord();
+    assert.strictEqual(d.x, 1);
+    assert.ok("y" in d && !("z" in d));
+    assert.strictEqual(String(d), "[object Object]");
+    assert.deepStrictEqual(Object.keys(d), ["x", "y"]);
+    assert.strictEqual(JSON.stringify(d), '{"x":1,"y":"two"}');
+
+    d.z = [1];
+    assert.deepStrictEqual({ ...d }, { x: 1, y: "two", z: [1] });
+    assert.throws(() => {
+      "use strict";
+      d._hidden = 1;
+    }, TypeError);
+    delete d.y;
+    assert.deepStrictEqual(Object.keys(d), ["x", "z"]);
+
+    const sym = Symbol("tag");
+    d[sym] = "local";
+    assert.strictEqual(d[sym], "local");
+    assert.deepStrictEqual(Object.getOwnPropertySymbols(d), [sym]);
+    assert.deepStrictEqual(Reflect.ownKeys(d), ["x", "z", sym]);
+
+    Object.defineProperty(d, "w", { value: 3 });
+    assert.strictEqual(d.w, 3);
+    assert.deepStrictEqual(Object.keys(d), ["x", "z", "w"]);
+    assert.throws(() => Object.defineProperty(d, "v", { get: () => 1 }), TypeError);
+    assert.throws(() => Object.defineProperty(d, "v", { value: 1, enumerable: false }), TypeError);
+    assert.throws(() => Object.defineProperty(d, "_v", { value: 1 }), TypeError);
+
+    Object.freeze(d);
+    assert.ok(Object.isFrozen(d));
+    assert.deepStrictEqual(Reflect.ownKeys(d), ["x", "z", "w", sym]);
+    assert.strictEqual(d.w, 3);
+    assert.throws(() => {
+      "use strict";
+      d.x = 2;
+    }, TypeError);
+    assert.strictEqual(d.x, 1);
+  },
+
+  handles: async (m) => {
+    const obj = { kept: true };
+    m.remember("strong", obj);
+    m.remember("weak", obj, true);
+    (() => m.remember("dropped", { kept: false }, true))();
+    (() => m.remember("held", { kept: "by the ref" }))();
+    if (globalThis.gc) {
+      await collect(() => m.recall("dropped") === undefined);
+      assert.strictEqual(m.recall("dropped"), undefined);
+    }
+
+    assert.strictEqual(m.recall("strong"), obj);
+    assert.strictEqual(m.recall("weak"), obj);
+    assert.deepStrictEqual(m.recall("held"), { kept: "by the ref" });
+    assert.match(m.recallFromGoroutine("strong"), /outside of the JS thread/);
+
+    m.forget("held");
+    assert.throws(() => m.recall("held"), /reference has been deleted/);
+
+    assert.strictEqual(m.sumInScopes(100000), (100000 * 99999) / 2);
+    assert.deepStrictEqual(m.escape("out"), { s: "out" });
+    const numbers = m.numbers(100000);
+    assert.strictEqual(numbers.length, 100000);
+    assert.ok(numbers.every((n, i) => n === i));
+  },
+
+  js: async (m) => {
+    assert.strictEqual(m.getMap().function(), "hello world");
+    assert.strictEqual(m.getArray().length, 4);
+    assert.strictEqual(m.getCallback()(1).args[0], 1);
+    assert.strictEqual(await m.getPromiseResolve(), "resolved");
+    await assert.rejects(m.getPromiseReject(), /rejected/);
+
+    const counts = [];
+    for await (const i of m.getCountdown()) counts.push(i);
+    assert.deepStrictEqual(counts, [3, 2, 1]);
+
+    // return() stops receiving from the channel
+    const numbers = m.getNumbers();
+    const received = [];
+    for await (const n of numbers) {
+      received.push(n);
+      if (n === 2) break;
+    }
+    assert.deepStrictEqual(received, [0, 1, 2]);
+    assert.strictEqual((await numbers.next()).done, true);
+
+    // a panicking producer rejects next() instead of crashing the process
+    const failing = m.getFailing("first");
+    assert.deepStrictEqual(await failing.next(), { value: "first", done: false });
+    await assert.rejects(failing.next(), /panic: producer failed/);
+
+    const ticks = [];
+    const ticker = m.getTicker();
+    ticker.on("tick", (i) => ticks.push(i));
+    await new Promise((resolve) => ticker.once("done", resolve));
+    assert.deepStrictEqual(ticks, [0, 1, 2]);
+
+    await new Promise((resolve) => m.callLater(resolve));
+
+    // events are emitted in the async context that created the emitter
+    const als = new AsyncLocalStorage();
+    const stores = [];
+    const contextTicker = als.run("ticker", () => m.getTicker());
+    contextTicker.on("tick", () => stores.push(als.getStore()));
+    await new Promise((resolve) => contextTicker.once("done", resolve));
+    assert.deepStrictEqual(stores, ["ticker", "ticker", "ticker"]);
+
+    const later = await als.run("later", () => new Promise((resolve) => m.callLater(() => resolve(als.getStore()))));
+    assert.strictEqual(later, "later");
+
+    // AbortSignal to context.Context
+    assert.strictEqual(await m.sleep(10, new AbortController().signal), "slept");
+    const controller = new AbortController();
+    const sleeping = m.sleep(10000, controller.signal);
+    controller.abort(new RangeError("stop"));
+    await assert.rejects(sleeping, /RangeError: stop/);
+    await assert.rejects(m.sleep(10000, AbortSignal.abort()), /AbortError/);
+
+    // context.Context to AbortSignal; the signal does not keep node alive
+    const signal = m.timeout(10);
+    assert.strictEqual(signal.aborted, false);
+    const alive = setInterval(() => {}, 1000);
+    await new Promise((resolve) => signal.addEventListener("abort", resolve));
+    clearInterval(alive);
+    assert.match(String(signal.reason), /timed out/);
+  },
+
+  logging: async (m) => {
+    m.captureLogs();
+    const warnings = [];
+    const onWarning = (w) => warnings.push(w);
+    process.on("warning", onWarning);
+    try {
+      assert.throws(() => m.panics("boom"), /boom/);
+      await new Promise((resolve) => setImmediate(resolve));
+    } finally {
+      process.off("warning", onWarning);
+      m.restoreLogs();
+    }
+
+    const records = m.logs().map((r) => JSON.parse(r));
+    assert.strictEqual(records.length, 1);
+    assert.strictEqual(records[0].level, "ERROR");
+    assert.strictEqual(records[0].msg, "Recovered from panic");
+    assert.strictEqual(records[0].source, "napi.ExecuteCallback");
+    assert.strictEqual(records[0].panic, "boom");
+    assert.match(records[0].stack, /main\.Panics/);
+
+    assert.strictEqual(warnings.length, 1);
+    assert.strictEqual(warnings[0].name, "GoPanicWarning");
+    assert.match(warnings[0].message, /napi\.ExecuteCallback: boom/);
+    assert.match(warnings[0].detail, /main\.Panics/);
+
+    // logs go back to stderr
+    assert.throws(() => m.panics("quiet"), /quiet/);
+    assert.deepStrictEqual(m.logs(), []);
+  },
+
+  methods: async (m) => {
+    const c = m.newCounter();
+    assert.strictEqual(c.Add(2), 2);
+    assert.strictEqual(c.Sum(), 2);
+    assert.strictEqual(c.Sum(1, 2, 3), 8);
+    assert.strictEqual(c.Describe("n="), "n=8");
+    assert.deepStrictEqual(c.Split(), [8, "8"]);
+    assert.strictEqual(c.Fail(""), undefined);
+    assert.throws(() => c.Fail("boom"), /boom/);
+    assert.throws(() => c.Add("x"), TypeError);
+    assert.strictEqual(m.counterValue(c), 8);
+    assert.strictEqual(Object.getPrototypeOf(m.newCounter()), Object.getPrototypeOf(c));
+
+    const other = { Add: c.Add };
+    assert.throws(() => other.Add(1), TypeError);
+    assert.throws(() => m.counterValue(m.newGreeter("x")), TypeError);
+
+    // objects are tagged with the Go type they wrap
+    const p = m.newPoint(1.5, 2);
+    assert.strictEqual(m.pointX(p), 1.5);
+    assert.strictEqual(m.pointX(m.newPoint(-1, 0)), -1);
+    const [wrapError, wrapped] = m.wrapTagged({});
+    assert.match(wrapError, /TypeTagObject: failed \(napi_invalid_arg\)/);
+    assert.strictEqual(wrapped, false);
+    assert.throws(() => m.pointX(c), /TypeError: Unwrap: value is not a wrapped main\.Point/);
+    assert.throws(() => m.counterValue(p), /not a wrapped main\.Counter/);
+    for (const other of [{}, { X: 1 }, () => {}, 1, "p", null, undefined]) {
+      assert.throws(() => m.pointX(other), TypeError);
+    }
+
+    const g = m.newGreeter("go");
+    assert.strictEqual(g.Greet(), "hello go");
+    assert.strictEqual(g.Secret, undefined);
+    assert.strictEqual(g.JSMethods, undefined);
+    assert.throws(() => m.newBroken(), /no such exported method: \*main\.Broken\.Missing/);
+  },
+
+  streams: async (m) => {
+    let data = "";
+    for await (const chunk of m.getReadable()) data += chunk;
+    assert.strictEqual(data, "hello world\n");
+
+    const als = new AsyncLocalStorage();
+    const readable = als.run("readable", () => m.getReadable());
+    const stores = new Set();
+    readable.on("data", () => stores.add(als.getStore()));
+    await new Promise((resolve) => readable.on("end", resolve));
+    assert.deepStrictEqual([...stores], ["readable"]);
+
+    let written = "";
+    await new Promise((resolve, reject) => {
+      const w = new Writable({
+        write(chunk, _, cb) {
+          written += chunk;
+          cb();
+        },
+      });
+      w.on("finish", resolve).on("error", reject);
+      m.writeTo(w);
+    });
+    assert.strictEqual(written, "hello world\n");
+
+    // destroying a stream closes its reader, even while a read is blocked
+    const stalled = m.getStalled();
+    stalled.resume();
+    await new Promise((resolve) => setTimeout(resolve, 10));
+    stalled.destroy();
+    assert.strictEqual(await m.stalledClosed(), true);
+  },
+
+  symbols: async (m) => {
+    const range = m.newRange(3);
+    assert.deepStrictEqual([...range], [0, 1, 2]);
+    assert.deepStrictEqual([...range], [0, 1, 2]);
+    assert.strictEqual(util.inspect(range), "Range(3)");
+
+    const obj = {};
+    const sym = m.tag(obj, "mine");
+    assert.strictEqual(typeof sym, "symbol");
+    assert.strictEqual(obj[sym], "tagged");
+    assert.strictEqual(m.readTag(obj, sym), "tagged");
+    assert.strictEqual(m.readTag(obj, Symbol("mine")), undefined);
+    assert.notStrictEqual(m.tag({}, "mine"), sym);
+    assert.throws(() => m.readTag(obj, "mine"), /cannot be decoded/);
+
+    assert.deepStrictEqual(m.describe(sym), ["mine", "Symbol(mine)"]);
+    assert.deepStrictEqual(m.describe(Symbol()), ["", "Symbol()"]);
+
+    assert.strictEqual(m.wellKnown("asyncIterator"), Symbol.asyncIterator);
+    assert.strictEqual(m.wellKnown("hasInstance"), Symbol.hasInstance);
+    assert.throws(() => m.wellKnown("nope"), /no such well-known symbol/);
+    assert.strictEqual(m.registered("napi-go"), Symbol.for("napi-go"));
+    assert.strictEqual(Symbol.keyFor(m.registered("napi-go")), "napi-go");
+  },
+
+  values: async (m) => {
+    const strings = ["", "ascii only", "héllo wörld", "emoji 🙂 and 中文"];
+    const externals = strings.map((s) => m.externalString(s));
+    globalThis.gc?.();
+    assert.deepStrictEqual(externals, strings);
+    assert.strictEqual(externals.join("|").length, strings.join("|").length);
+
+    const [latin1, utf16] = m.readStrings("héllo 🙂");
+    assert.deepStrictEqual(latin1, Buffer.from("héllo 🙂", "latin1"));
+    assert.strictEqual(utf16, "héllo 🙂");
+
+    const record = (i) => ({
+      ID: i,
+      Name: `record ${i}`,
+      Tags: ["a", "b"],
+      Score: i / 2,
+      Next: null,
+    });
+    for (const n of [1, 10, 1000]) {
+      assert.deepStrictEqual(m.records(n, -1), Array.from({ length: n }, (_, i) => record(i)));
+    }
+    assert.deepStrictEqual(m.labels(100)[99], { Name: "99" });
+    const mixed = m.records(1000, 500);
+    assert.deepStrictEqual(mixed[499], record(499));
+    assert.deepStrictEqual(mixed[500].bytes, Buffer.from("go"));
+    assert.deepStrictEqual(mixed[500].map, new Map([["one", 1]]));
+    assert.deepStrictEqual(mixed[500].set, new Set(["x"]));
+
+    const plain = { n: 1.5, s: "x", b: true, nil: null, list: [1, "two", [3]], nested: { k: "v" } };
+    assert.deepStrictEqual(m.roundTrip(plain), plain);
+    const map = new Map([[1, "one"], ["two", { n: 2 }]]);
+    assert.deepStrictEqual(m.roundTrip(map), map);
+    assert.deepStrictEqual(m.roundTrip(new Set(["a", 1])), ["a", 1]);
+    assert.deepStrictEqual(m.decodeRecord(record(7)), record(7));
+    assert.deepStrictEqual(m.decodeRecord({ ...record(1), Next: record(2) }), { ...record(1), Next: record(2) });
+    assert.throws(() => m.decodeRecord({ ID: "one" }), /ID|string|int/);
+
+    assert.deepStrictEqual(m.invert(new Map([["one", 1], ["two", 2]])), new Map([[1, "one"], [2, "two"]]));
+    assert.deepStrictEqual(m.invert({ three: 3 }), new Map([[3, "three"]]));
+    assert.deepStrictEqual(m.unique(new Set(["a", "b"])), [new Set(["a", "b"]), 2]);
+    assert.deepStrictEqual(m.unique(["b", "a", "b"]), [new Set(["b", "a"]), 2]);
+    const keys = Array.from({ length: 100000 }, (_, i) => `k${i}`);
+    assert.deepStrictEqual(m.unique(keys), [new Set(keys), keys.length]);
+    assert.deepStrictEqual(
+      m.unique((function* () {
+        yield "x";
+        yield "x";
+      })()),
+      [new Set(["x"]), 1],
+    );
+
+    const names = ["id", "héllo", "ключ", "🙂", "", "id"];
+    assert.deepStrictEqual(m.keyed(names), [{ id: 5, héllo: 1, ключ: 2, "🙂": 3, "": 4 }, true]);
+    // more names than the env caches
+    const many = Array.from({ length: 5000 }, (_, i) => `key${i}`);
+    const [manyKeyed, manySame] = m.keyed(many);
+    assert.strictEqual(manySame, true);
+    assert.deepStrictEqual(Object.keys(manyKeyed), many);
+    assert.strictEqual(manyKeyed.key4999, 4999);
+
+    assert.strictEqual(m.decodeAs("int8", -128), -128);
+    assert.strictEqual(m.decodeAs("uint8", 255), 255);
+    assert.strictEqual(m.decodeAs("int64", 2 ** 53), 2 ** 53);
+    assert.strictEqual(m.decodeAs("int64", -5n), -5);
+    assert.strictEqual(m.decodeAs("uint64", 7n), 7);
+    for (const [kind, x] of [
+      ["int8", 128],
+      ["int8", 300],
+      ["uint8", 256],
+      ["uint8", -1],
+      ["int64", 1.5],
+      ["int64", NaN],
+      ["int64", Infinity],
+      ["int64", 2 ** 63],
+      ["uint64", -1],
+      ["uint64", 2 ** 64],
+      ["int8", 128n],
+      ["uint64", -1n],
+      ["int64", 2n ** 64n],
+    ]) {
+      assert.match(m.decodeAs(kind, x), new RegExp(`cannot be decoded into Go type ${kind}$`), `${kind} ${x}`);
+    }
+
+    assert.strictEqual(m.stringError("go"), null);
+    assert.match(m.stringError(1), /^napi\.GetValueStringUtf8: .+ \(napi_string_expected\)$/);
+
+    if (globalThis.gc) {
+      (() => {
+        const obj = {};
+        m.onCollect(obj);
+        m.onCollect(obj);
+      })();
+      await collect(() => m.collected() >= 2);
+      assert.strictEqual(m.collected(), 2);
+    }
+  },
+};
+
+let done = false;
+process.on("exit", () => {
+  // node exits early when a check awaits a promise that nothing settles
+  if (!done) {
+    console.error("exited before all checks completed");
+    process.exitCode = 1;
+  }
+});
+
+(async () => {
+  for (const [name, run] of Object.entries(examples)) {
+    await run(load(name));
+    globalThis.gc?.();
+    console.log(`ok ${name}`);
+  }
+  done = true;
+})().catch((err) => {
+  console.error(err);
+  process.exit(1);
+});
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/abhisekp/napi-go/entry"
+	"github.com/abhisekp/napi-go/js"
+)
+
+func init() {
+	entry.Export("getReadable", js.AsCallback(GetReadable))
+	entry.Export("writeTo", js.AsCallback(WriteTo))
+	entry.Export("getStalled", js.AsCallback(GetStalled))
+	entry.Export("stalledClosed", js.AsCallback(StalledClosed))
+}
+
+func GetReadable(env js.Env, this js.Value, args []js.Value) any {
+	return env.ReadableFrom(strings.NewReader("hello world\n"))
+}
+
+func WriteTo(env js.Env, this js.Value, args []js.Value) any {
+	w := args[0].AsWriter()
+
+	go func() {
+		defer w.Close()
+		io.WriteString(w, "hello world\n")
+	}()
+
+	return nil
+}
+
+// stalledReader never produces anything until it is closed.
+type stalledReader struct {
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+func (r *stalledReader) Read(p []byte) (int, error) {
+	<-r.closed
+	return 0, io.ErrClosedPipe
+}
+
+func (r *stalledReader) Close() error {
+	r.closeOnce.Do(func() { close(r.closed) })
+	return nil
+}
+
+var stalled *stalledReader
+
+// GetStalled returns a stream over a reader whose reads block until it is
+// closed.
+func GetStalled(env js.Env, this js.Value, args []js.Value) any {
+	stalled = &stalledReader{closed: make(chan struct{})}
+	return env.ReadableFrom(stalled)
+}
+
+// StalledClosed returns a promise that resolves to whether the reader of
+// GetStalled is closed within a second.
+func StalledClosed(env js.Env, this js.Value, args []js.Value) any {
+	promise := env.NewPromise()
+	go func() {
+		select {
+		case <-stalled.closed:
+			promise.Resolve(true)
+		case <-time.After(time.Second):
+			promise.Resolve(false)
+		}
+	}()
+	return promise
+}
+
+func main() {}
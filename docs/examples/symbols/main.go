@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/abhisekp/napi-go/entry"
+	"github.com/abhisekp/napi-go/js"
+)
+
+func init() {
+	entry.Export("newRange", js.AsCallback(NewRange))
+	entry.Export("tag", js.AsCallback(Tag))
+	entry.Export("readTag", js.AsCallback(ReadTag))
+	entry.Export("describe", js.AsCallback(Describe))
+	entry.Export("wellKnown", js.AsCallback(WellKnown))
+	entry.Export("registered", js.AsCallback(Registered))
+}
+
+// NewRange returns an iterable of 0 to n-1, for the n given as argument,
+// which util.inspect shows as Range(n).
+func NewRange(env js.Env, this js.Value, args []js.Value) any {
+	n := args[0].Int()
+	obj := env.ValueOf(map[string]any{"n": n})
+
+	obj.SetSymbol(env.WellKnownSymbol("iterator"), func(env js.Env, this js.Value, args []js.Value) any {
+		i := 0
+		return map[string]any{
+			"next": func(env js.Env, this js.Value, args []js.Value) any {
+				if i >= n {
+					return map[string]any{"done": true}
+				}
+				i++
+				return map[string]any{"value": i - 1, "done": false}
+			},
+		}
+	})
+	obj.SetSymbol(env.SymbolFor("nodejs.util.inspect.custom"), func(env js.Env, this js.Value, args []js.Value) any {
+		return fmt.Sprintf("Range(%d)", n)
+	})
+	return obj
+}
+
+// Tag sets "tagged" on the object given first under a new symbol described
+// by the string given second, and returns the symbol.
+func Tag(env js.Env, this js.Value, args []js.Value) any {
+	sym := env.NewSymbol(args[1].String())
+	args[0].SetSymbol(sym, "tagged")
+	return sym
+}
+
+// ReadTag returns the property of the object given first keyed by the
+// symbol given second.
+func ReadTag(env js.Env, this js.Value, args []js.Value) any {
+	var sym js.Symbol
+	if err := args[1].Decode(&sym); err != nil {
+		panic(err)
+	}
+	return args[0].GetSymbol(sym)
+}
+
+// Describe returns the description and string form of the symbol given as
+// argument.
+func Describe(env js.Env, this js.Value, args []js.Value) any {
+	var sym js.Symbol
+	if err := args[0].Decode(&sym); err != nil {
+		panic(err)
+	}
+	return []string{sym.Description(), sym.String()}
+}
+
+// WellKnown returns the well-known symbol named by its argument.
+func WellKnown(env js.Env, this js.Value, args []js.Value) any {
+	return env.WellKnownSymbol(args[0].String())
+}
+
+// Registered returns the symbol registered for its argument.
+func Registered(env js.Env, this js.Value, args []js.Value) any {
+	return env.SymbolFor(args[0].String())
+}
+
+func main() {}
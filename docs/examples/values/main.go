@@ -0,0 +1,215 @@
+package main
+
+import (
+	"fmt"
+	"unicode/utf16"
+	"unsafe"
+
+	"github.com/abhisekp/napi-go"
+	"github.com/abhisekp/napi-go/entry"
+	"github.com/abhisekp/napi-go/js"
+)
+
+func init() {
+	entry.Export("externalString", js.AsCallback(ExternalString))
+	entry.Export("readStrings", js.AsCallback(ReadStrings))
+	entry.Export("records", js.AsCallback(Records))
+	entry.Export("onCollect", js.AsCallback(OnCollect))
+	entry.Export("collected", js.AsCallback(Collected))
+	entry.Export("stringError", js.AsCallback(StringError))
+	entry.Export("roundTrip", js.AsCallback(RoundTrip))
+	entry.Export("decodeRecord", js.AsCallback(DecodeRecord))
+	entry.Export("invert", js.AsCallback(Invert))
+	entry.Export("unique", js.AsCallback(Unique))
+	entry.Export("keyed", js.AsCallback(Keyed))
+	entry.Export("decodeAs", js.AsCallback(DecodeAs))
+	entry.Export("labels", js.AsCallback(Labels))
+}
+
+// ExternalString returns its argument as an external string.
+func ExternalString(env js.Env, this js.Value, args []js.Value) any {
+	return env.ExternalString(args[0].String())
+}
+
+// ReadStrings returns its argument as read in Latin-1 and in UTF-16.
+func ReadStrings(env js.Env, this js.Value, args []js.Value) any {
+	latin1, st := napi.GetValueStringLatin1(env.Env, args[0].Value)
+	if st != napi.StatusOK {
+		panic(napi.NewError(env.Env, "GetValueStringLatin1", st))
+	}
+	units, st := napi.GetValueStringUtf16(env.Env, args[0].Value)
+	if st != napi.StatusOK {
+		panic(napi.NewError(env.Env, "GetValueStringUtf16", st))
+	}
+	return []any{[]byte(latin1), string(utf16.Decode(units))}
+}
+
+type Record struct {
+	ID    int
+	Name  string
+	Tags  []string
+	Score float64
+	Next  *Record
+}
+
+// Records returns n records. Large payloads are converted through JSON,
+// except where their values need more than JSON, such as the Buffer, Map and
+// Set returned for the records whose index is given in the second argument.
+func Records(env js.Env, this js.Value, args []js.Value) any {
+	records := make([]any, args[0].Int())
+	for i := range records {
+		records[i] = &Record{
+			ID:    i,
+			Name:  fmt.Sprint("record ", i),
+			Tags:  []string{"a", "b"},
+			Score: float64(i) / 2,
+		}
+	}
+	if special := args[1].Int(); special >= 0 {
+		records[special] = map[string]any{
+			"bytes": []byte("go"),
+			"map":   js.AsMap(map[string]int{"one": 1}),
+			"set":   js.AsSet([]string{"x"}),
+		}
+	}
+	return records
+}
+
+var collected int
+
+// OnCollect counts its argument in Collected once it is garbage collected.
+func OnCollect(env js.Env, this js.Value, args []js.Value) any {
+	st := napi.AddFinalizer(env.Env, args[0].Value, nil, func(napi.Env, unsafe.Pointer, unsafe.Pointer) {
+		collected++
+	}, nil)
+	if st != napi.StatusOK {
+		panic(napi.NewError(env.Env, "AddFinalizer", st))
+	}
+	return nil
+}
+
+// Collected returns the number of values collected since OnCollect.
+func Collected(env js.Env, this js.Value, args []js.Value) any {
+	return collected
+}
+
+// StringError returns the error of reading its argument as a string, or null.
+func StringError(env js.Env, this js.Value, args []js.Value) any {
+	_, err := napi.Check(env.Env).GetValueStringUtf8(args[0].Value)
+	if err != nil {
+		return err.Error()
+	}
+	return nil
+}
+
+// RoundTrip decodes its argument into an empty interface and returns it.
+func RoundTrip(env js.Env, this js.Value, args []js.Value) any {
+	var x any
+	if err := args[0].Decode(&x); err != nil {
+		panic(err)
+	}
+	return x
+}
+
+// DecodeRecord decodes its argument into a Record and returns it.
+func DecodeRecord(env js.Env, this js.Value, args []js.Value) any {
+	var r Record
+	if err := args[0].Decode(&r); err != nil {
+		panic(err)
+	}
+	return &r
+}
+
+// Invert returns a Map from the values of the Map or object given as
+// argument to their keys.
+func Invert(env js.Env, this js.Value, args []js.Value) any {
+	var m map[string]int
+	if err := args[0].Decode(&m); err != nil {
+		panic(err)
+	}
+
+	inverted := make(map[int]string, len(m))
+	for k, v := range m {
+		inverted[v] = k
+	}
+	return js.AsMap(inverted)
+}
+
+// Unique returns a Set of the strings of the iterable given as argument, and
+// their number once decoded as a Set.
+func Unique(env js.Env, this js.Value, args []js.Value) any {
+	var list []string
+	if err := args[0].Decode(&list); err != nil {
+		panic(err)
+	}
+
+	set := env.ValueOf(js.AsSet(list))
+	var m map[string]struct{}
+	if err := set.Decode(&m); err != nil {
+		panic(err)
+	}
+	return []any{set, len(m)}
+}
+
+// Keyed returns an object mapping the names given as argument to their
+// index, set with the keys of env.Key, and whether looking up each key again
+// returned the same string.
+func Keyed(env js.Env, this js.Value, args []js.Value) any {
+	var names []string
+	if err := args[0].Decode(&names); err != nil {
+		panic(err)
+	}
+
+	obj := env.ValueOf(map[string]any{})
+	same := true
+	for i, name := range names {
+		key := env.Key(name)
+		st := napi.SetProperty(env.Env, obj.Value, key.Value, env.ValueOf(i).Value)
+		if st != napi.StatusOK {
+			panic(napi.NewError(env.Env, "SetProperty", st))
+		}
+		same = same && key.Equal(env.Key(name))
+	}
+	return []any{obj, same}
+}
+
+// DecodeAs decodes the value given second into the Go integer type named
+// first, and returns it or the error message.
+func DecodeAs(env js.Env, this js.Value, args []js.Value) any {
+	var target any
+	switch args[0].String() {
+	case "int8":
+		target = new(int8)
+	case "uint8":
+		target = new(uint8)
+	case "int64":
+		target = new(int64)
+	case "uint64":
+		target = new(uint64)
+	}
+
+	if err := args[1].Decode(target); err != nil {
+		return err.Error()
+	}
+	return target
+}
+
+// Label marshals to text through its pointer, which ValueOf does not use.
+type Label struct {
+	Name string
+}
+
+func (l *Label) MarshalText() ([]byte, error) {
+	return []byte("label " + l.Name), nil
+}
+
+// Labels returns as many Labels as its argument asks for.
+func Labels(env js.Env, this js.Value, args []js.Value) any {
+	result := make([]Label, args[0].Int())
+	for i := range result {
+		result[i].Name = fmt.Sprint(i)
+	}
+	return result
+}
+
+func main() {}
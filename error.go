@@ -0,0 +1,91 @@
+package napi
+
+import (
+	"fmt"
+)
+
+// ExtendedErrorInfo describes the last failed Node-API call of an env.
+type ExtendedErrorInfo struct {
+	Message         string
+	EngineErrorCode uint32
+	Status          Status
+}
+
+// Error is a failed Node-API call.
+type Error struct {
+	// Func is the name of the failing function, such as "GetProperty".
+	Func   string
+	Status Status
+	// Message is the message reported by Node, if any.
+	Message string
+	// ExceptionPending reports whether a JS exception was pending after the
+	// call, which is the case when the call ran JS code that threw.
+	ExceptionPending bool
+}
+
+var _ error = (*Error)(nil)
+
+// Checked has a method for every function of the package that takes an Env
+// first and returns a Status last. The method calls the function with Env,
+// and returns the Status as an error made by NewError, so that failures can
+// be handled like any other Go error:
+//
+//	result, err := napi.Check(env).GetProperty(object, key)
+//	if err != nil {
+//		return err
+//	}
+//
+// The methods are generated by napigen, in zz_checked.go.
+type Checked struct {
+	Env Env
+}
+
+// Check returns the Checked functions of env.
+func Check(env Env) Checked {
+	return Checked{Env: env}
+}
+
+// NewError returns nil if status is StatusOK, and otherwise an *Error that
+// describes the failed call of fn. It must be called on the JS thread right
+// after the failing call, before any other Node-API call on env. If env is
+// nil, only fn and status are recorded.
+func NewError(env Env, fn string, status Status) error {
+	if status == StatusOK {
+		return nil
+	}
+
+	err := &Error{
+		Func:   fn,
+		Status: status,
+	}
+	if env == nil {
+		return err
+	}
+
+	if info, st := GetExtendedErrorInfo(env); st == StatusOK {
+		err.Message = info.Message
+	}
+	if pending, st := IsExceptionPending(env); st == StatusOK {
+		err.ExceptionPending = pending
+	}
+	return err
+}
+
+func (err *Error) Error() string {
+	msg := err.Message
+	if msg == "" {
+		msg = "failed"
+	}
+
+	result := fmt.Sprintf("napi.%s: %s (%s)", err.Func, msg, err.Status)
+	if err.ExceptionPending {
+		result += ", exception pending"
+	}
+	return result
+}
+
+// Unwrap returns the StatusError of err, so that errors.Is can match the
+// status.
+func (err *Error) Unwrap() error {
+	return StatusError(err.Status)
+}
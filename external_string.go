@@ -0,0 +1,192 @@
+package napi
+
+/*
+#include <stdint.h>
+#include <node/node_api.h>
+
+extern void FinalizeExternalString(
+	node_api_basic_env env,
+	void *data,
+	uintptr_t handle
+);
+
+static void napi_go_finalize_external_string(
+	node_api_basic_env env,
+	void *data,
+	void *hint
+) {
+	FinalizeExternalString(env, data, (uintptr_t)hint);
+}
+
+static napi_status napi_go_create_external_string_latin1(
+	napi_env env,
+	char *str,
+	size_t length,
+	uintptr_t handle,
+	napi_value *result,
+	bool *copied
+) {
+	return node_api_create_external_string_latin1(
+		env,
+		str,
+		length,
+		napi_go_finalize_external_string,
+		(void *)handle,
+		result,
+		copied
+	);
+}
+
+static napi_status napi_go_create_external_string_utf16(
+	napi_env env,
+	char16_t *str,
+	size_t length,
+	uintptr_t handle,
+	napi_value *result,
+	bool *copied
+) {
+	return node_api_create_external_string_utf16(
+		env,
+		str,
+		length,
+		napi_go_finalize_external_string,
+		(void *)handle,
+		result,
+		copied
+	);
+}
+*/
+import "C"
+
+import (
+	"runtime"
+	"runtime/cgo"
+	"unsafe"
+)
+
+// externalString keeps the data of an external string pinned until V8
+// releases it.
+type externalString struct {
+	pinner   runtime.Pinner
+	finalize Finalize
+	hint     unsafe.Pointer
+}
+
+// CreateExternalStringLatin1 creates a JS string backed by the bytes of str,
+// which must be Latin-1 encoded, without copying them. str is pinned until
+// the string is garbage collected, after which finalize, if not nil, is
+// called with the string data. finalize may be called with a nil env during
+// teardown. copied reports whether Node copied str anyway, in which case
+// finalize has already been called.
+func CreateExternalStringLatin1(
+	env Env,
+	str string,
+	finalize Finalize,
+	finalizeHint unsafe.Pointer,
+) (Value, bool, Status) {
+	debugCall(env, "CreateExternalStringLatin1")
+
+	if len(str) == 0 {
+		result, status := CreateStringLatin1(env, str)
+		return result, true, status
+	}
+
+	data := unsafe.StringData(str)
+	h := newExternalString(unsafe.Pointer(data), finalize, finalizeHint)
+
+	var (
+		result Value
+		copied C.bool
+	)
+	status := Status(C.napi_go_create_external_string_latin1(
+		C.napi_env(env),
+		(*C.char)(unsafe.Pointer(data)),
+		C.size_t(len(str)),
+		C.uintptr_t(h),
+		(*C.napi_value)(unsafe.Pointer(&result)),
+		&copied,
+	))
+
+	if status != StatusOK {
+		deleteExternalString(h)
+	}
+	debugResult(env, result)
+	return result, bool(copied), status
+}
+
+// CreateExternalStringUtf16 is like CreateExternalStringLatin1, but for UTF-16
+// encoded strings.
+func CreateExternalStringUtf16(
+	env Env,
+	str []uint16,
+	finalize Finalize,
+	finalizeHint unsafe.Pointer,
+) (Value, bool, Status) {
+	debugCall(env, "CreateExternalStringUtf16")
+
+	if len(str) == 0 {
+		result, status := CreateStringLatin1(env, "")
+		return result, true, status
+	}
+
+	data := &str[0]
+	h := newExternalString(unsafe.Pointer(data), finalize, finalizeHint)
+
+	var (
+		result Value
+		copied C.bool
+	)
+	status := Status(C.napi_go_create_external_string_utf16(
+		C.napi_env(env),
+		(*C.char16_t)(unsafe.Pointer(data)),
+		C.size_t(len(str)),
+		C.uintptr_t(h),
+		(*C.napi_value)(unsafe.Pointer(&result)),
+		&copied,
+	))
+
+	if status != StatusOK {
+		deleteExternalString(h)
+	}
+	debugResult(env, result)
+	return result, bool(copied), status
+}
+
+func newExternalString(
+	data unsafe.Pointer,
+	finalize Finalize,
+	hint unsafe.Pointer,
+) cgo.Handle {
+	s := &externalString{
+		finalize: finalize,
+		hint:     hint,
+	}
+	s.pinner.Pin(data)
+	return cgo.NewHandle(s)
+}
+
+func deleteExternalString(h cgo.Handle) *externalString {
+	s := h.Value().(*externalString)
+	h.Delete()
+	s.pinner.Unpin()
+	return s
+}
+
+//export FinalizeExternalString
+func FinalizeExternalString(
+	cEnv C.node_api_basic_env,
+	data unsafe.Pointer,
+	handle C.uintptr_t,
+) {
+	defer func() {
+		err := recover()
+		if err != nil {
+			HandlePanic(nil, "napi.FinalizeExternalString", err)
+		}
+	}()
+
+	s := deleteExternalString(cgo.Handle(handle))
+	if s.finalize != nil {
+		s.finalize(Env(unsafe.Pointer(cEnv)), data, s.hint)
+	}
+}
@@ -0,0 +1,3 @@
+package napi
+
+//go:generate go run ./internal/napigen -out zz_generated.go -checked zz_checked.go
@@ -1,6 +1,7 @@
 package napi
 
 /*
+#include <pthread.h>
 #include <stdlib.h>
 #include <node/node_api.h>
 
@@ -16,7 +17,8 @@ extern void DeleteCallbackData(
 	void *finalize_hint
 );
 
-extern napi_value ExecuteCallback(
+// napi_go_execute_callback is defined in callback.go.
+napi_value napi_go_execute_callback(
 	napi_env env,
 	napi_callback_info info
 );
@@ -31,12 +33,18 @@ extern void ExecuteAsyncCompleteCallback(
 	napi_status status,
 	void *data
 );
+
+extern void ExecuteFinalizeCallback(
+	napi_env env,
+	void *finalize_data,
+	void *finalize_hint
+);
+
+extern void ExecuteEnvCleanupHook(void *arg);
 */
 import "C"
 
 import (
-	"fmt"
-	"runtime"
 	"runtime/cgo"
 	"sync"
 	"unsafe"
@@ -46,6 +54,9 @@ type NapiGoInstanceData struct {
 	UserData      any
 	CallbackData  NapiGoInstanceCallbackData
 	AsyncWorkData NapiGoInstanceAsyncWorkData
+	FinalizerData NapiGoInstanceFinalizerData
+
+	thread C.pthread_t
 }
 
 type NapiGoInstanceCallbackData struct {
@@ -61,6 +72,8 @@ type NapiGoInstanceCallbackMap map[NapiGoCallbackID]*NapiGoCallbackMapEntry
 type NapiGoCallbackMapEntry struct {
 	Callback Callback
 	ID       NapiGoCallbackID
+
+	token unsafe.Pointer
 }
 
 type NapiGoAsyncWorkID int
@@ -76,19 +89,69 @@ type NapiGoInstanceAsyncWorkMap map[NapiGoAsyncWorkID]*NapiGoAsyncWorkMapEntry
 type NapiGoAsyncWorkMapEntry struct {
 	Execute  AsyncExecuteCallback
 	Complete AsyncCompleteCallback
+	Err      error
 	ID       NapiGoAsyncWorkID
+
+	token unsafe.Pointer
+}
+
+type NapiGoFinalizerID int
+
+type NapiGoInstanceFinalizerData struct {
+	FinalizerMap NapiGoInstanceFinalizerMap
+	NextID       NapiGoFinalizerID
+	Lock         sync.RWMutex
 }
 
+type NapiGoInstanceFinalizerMap map[NapiGoFinalizerID]*NapiGoFinalizerMapEntry
+
+type NapiGoFinalizerMapEntry struct {
+	Finalize Finalize
+	Hint     unsafe.Pointer
+	ID       NapiGoFinalizerID
+
+	token unsafe.Pointer
+}
+
+type NapiGoCleanupHookID int
+
+// NapiGoCleanupHookData is shared by all envs, since napi does not pass the
+// env to cleanup hooks.
+type NapiGoCleanupHookData struct {
+	CleanupHookMap NapiGoCleanupHookMap
+	NextID         NapiGoCleanupHookID
+	Lock           sync.RWMutex
+}
+
+type NapiGoCleanupHookMap map[NapiGoCleanupHookID]*NapiGoCleanupHookMapEntry
+
+type NapiGoCleanupHookMapEntry struct {
+	Hook func()
+	ID   NapiGoCleanupHookID
+
+	token unsafe.Pointer
+}
+
+var cleanupHookData NapiGoCleanupHookData
+
 type InstanceDataProvider interface {
 	GetUserData() any
 	SetUserData(userData any)
 
 	GetCallbackData() CallbackDataProvider
 	GetAsyncWorkData() AsyncWorkDataProvider
+	GetFinalizerData() FinalizerDataProvider
 }
 
 type CallbackDataProvider interface {
 	CreateCallback(env Env, name string, cb Callback) (Value, Status)
+	DefineProperties(env Env, object Value, properties []PropertyDescriptor) Status
+	DefineClass(
+		env Env,
+		name string,
+		constructor Callback,
+		properties []PropertyDescriptor,
+	) (Value, Status)
 	GetCallback(id NapiGoCallbackID) *NapiGoCallbackMapEntry
 	DeleteCallback(id NapiGoCallbackID)
 }
@@ -104,16 +167,56 @@ type AsyncWorkDataProvider interface {
 	DeleteAsyncWork(id NapiGoAsyncWorkID)
 }
 
+type FinalizerDataProvider interface {
+	CreateFinalizer(finalize Finalize, hint unsafe.Pointer) *NapiGoFinalizerMapEntry
+	GetFinalizer(id NapiGoFinalizerID) *NapiGoFinalizerMapEntry
+	DeleteFinalizer(id NapiGoFinalizerID)
+}
+
 var _ InstanceDataProvider = &NapiGoInstanceData{}
 var _ CallbackDataProvider = &NapiGoInstanceCallbackData{}
 var _ AsyncWorkDataProvider = &NapiGoInstanceAsyncWorkData{}
+var _ FinalizerDataProvider = &NapiGoInstanceFinalizerData{}
 
 const (
 	maxStackTraceSize = 8192
 )
 
+// InitializeInstanceData prepares env for use by napi-go. It must be called
+// on the JS thread of env, which it records for IsEnvThread.
 func InitializeInstanceData(env Env) Status {
-	return setInstanceData(env, &NapiGoInstanceData{})
+	debugRegisterEnv(env)
+	return setInstanceData(env, &NapiGoInstanceData{
+		thread: C.pthread_self(),
+	})
+}
+
+// IsEnvThread reports whether the caller is running on the JS thread of env.
+func IsEnvThread(env Env) bool {
+	handle, status := getInstanceDataHandle(env)
+	if status != StatusOK || handle == 0 {
+		return false
+	}
+
+	data, ok := handle.Value().(*NapiGoInstanceData)
+	return ok && C.pthread_equal(data.thread, C.pthread_self()) != 0
+}
+
+// Thread identifies an OS thread, such as the JS thread of an env.
+type Thread struct {
+	thread C.pthread_t
+}
+
+// CurrentThread returns the thread the caller is running on. Callbacks from
+// JS run on the JS thread of their env.
+func CurrentThread() Thread {
+	return Thread{thread: C.pthread_self()}
+}
+
+// IsCurrent reports whether the caller is running on t. Unlike IsEnvThread,
+// it makes no Node-API calls and may be used on any thread.
+func (t Thread) IsCurrent() bool {
+	return C.pthread_equal(t.thread, C.pthread_self()) != 0
 }
 
 //export DeleteInstanceData
@@ -121,8 +224,9 @@ func DeleteInstanceData(
 	env C.napi_env,
 	finalizeData, finalizeHint unsafe.Pointer,
 ) {
-	instanceDataHandle := cgo.Handle(finalizeData)
+	instanceDataHandle := *(*cgo.Handle)(finalizeData)
 	instanceDataHandle.Delete()
+	freeToken(finalizeData)
 }
 
 //export DeleteCallbackData
@@ -134,23 +238,16 @@ func DeleteCallbackData(
 	defer func() {
 		err := recover()
 		if err != nil {
-			fmt.Printf("napi.DeleteCallbackData: Recovered from panic: %s\n", err)
-			reportStackTrace()
-
-			msg := "unknown error"
-			if err, ok := err.(error); ok {
-				msg = err.Error()
-			}
-			ThrowError(env, "", msg)
+			HandlePanic(nil, "napi.DeleteCallbackData", err)
 		}
 	}()
 
 	instanceData, status := getInstanceData(env)
 	if status != StatusOK {
-		panic(StatusError(status))
+		panic(NewError(env, "GetInstanceData", status))
 	}
 
-	id := *(*NapiGoCallbackID)(finalizeData)
+	id := callbackEntry(finalizeData).ID
 	instanceData.GetCallbackData().DeleteCallback(id)
 }
 
@@ -158,13 +255,22 @@ func DeleteCallbackData(
 func ExecuteCallback(
 	cEnv C.napi_env,
 	cInfo C.napi_callback_info,
+	cStatus C.napi_status,
+	argc C.size_t,
+	argv *C.napi_value,
+	this C.napi_value,
+	cData unsafe.Pointer,
 ) C.napi_value {
 	env := Env(cEnv)
+	if debug {
+		// JS calls the callback inside a handle scope of its own
+		debugOpenScope(env)
+		defer debugCloseScope(env)
+	}
 	defer func() {
 		err := recover()
 		if err != nil {
-			fmt.Printf("napi.ExecuteCallback: Recovered from panic: %s\n", err)
-			reportStackTrace()
+			reportPanic(env, "napi.ExecuteCallback", err)
 
 			msg := "unknown error"
 			if err, ok := err.(error); ok {
@@ -174,62 +280,80 @@ func ExecuteCallback(
 		}
 	}()
 
-	instanceData, status := getInstanceData(env)
-	if status != StatusOK {
-		panic(StatusError(status))
+	if status := Status(cStatus); status != StatusOK {
+		panic(NewError(env, "GetCbInfo", status))
 	}
 
-	argc := C.size_t(0)
-	var cData unsafe.Pointer
-	status = Status(C.napi_get_cb_info(
-		cEnv,
-		cInfo,
-		&argc,
-		nil,
-		nil,
-		&cData,
-	))
-
-	if status != StatusOK {
-		panic(StatusError(status))
+	// this is the hot path of every call from JS, so the entry is reached
+	// through the handle stored in the function data rather than through
+	// the locked registry of the instance data, and the arguments are used
+	// in place when they fit on the stack of napi_go_execute_callback
+	info := CallbackInfo{
+		This: Value(this),
+		info: unsafe.Pointer(cInfo),
+	}
+	if argc <= maxStackArgs {
+		info.Args = unsafe.Slice((*Value)(unsafe.Pointer(argv)), int(argc))
+	} else {
+		info.Args = make([]Value, int(argc))
+		status := Status(C.napi_get_cb_info(
+			cEnv,
+			cInfo,
+			&argc,
+			(*C.napi_value)(unsafe.Pointer(&info.Args[0])), // must pass element pointer
+			nil,
+			nil,
+		))
+		if status != StatusOK {
+			panic(NewError(env, "GetCbInfo", status))
+		}
 	}
 
-	id := *(*NapiGoCallbackID)(cData)
-	callbackData := instanceData.GetCallbackData().GetCallback(id)
+	if debug {
+		debugResult(env, info.This)
+		for _, arg := range info.Args {
+			debugResult(env, arg)
+		}
+	}
 
-	info := CallbackInfo(cInfo)
-	result := callbackData.Callback(env, info)
+	result := callbackEntry(cData).Callback(env, info)
 	return C.napi_value(result)
 }
 
+// callbackEntry returns the entry whose handle is stored in the token data.
+func callbackEntry(data unsafe.Pointer) *NapiGoCallbackMapEntry {
+	return (*(*cgo.Handle)(data)).Value().(*NapiGoCallbackMapEntry)
+}
+
+// asyncWorkEntry returns the entry whose handle is stored in the token data.
+// It makes no Node-API calls, so it may be used on worker threads.
+func asyncWorkEntry(data unsafe.Pointer) *NapiGoAsyncWorkMapEntry {
+	entry, _ := (*(*cgo.Handle)(data)).Value().(*NapiGoAsyncWorkMapEntry)
+	return entry
+}
+
 //export ExecuteAsyncExecuteCallback
 func ExecuteAsyncExecuteCallback(cEnv C.napi_env, cData unsafe.Pointer) {
 	env := Env(cEnv)
+	var asyncWorkData *NapiGoAsyncWorkMapEntry
+
+	// this runs on a worker thread where neither throwing nor other Node-API
+	// calls are allowed, so panics are handed to the complete callback instead
 	defer func() {
 		err := recover()
 		if err != nil {
-			fmt.Printf(
-				"napi.ExecuteAsyncExecuteCallback: Recovered from panic: %s\n",
-				err,
-			)
-			reportStackTrace()
-
-			msg := "unknown error"
-			if err, ok := err.(error); ok {
-				msg = err.Error()
+			reportPanic(nil, "napi.ExecuteAsyncExecuteCallback", err)
+			if asyncWorkData != nil {
+				asyncWorkData.Err = PanicError{Value: err}
 			}
-			ThrowError(env, "", msg)
 		}
 	}()
 
-	instanceData, status := getInstanceData(env)
-	if status != StatusOK {
-		panic(StatusError(status))
+	asyncWorkData = asyncWorkEntry(cData)
+	if asyncWorkData == nil {
+		return
 	}
-
-	id := *(*NapiGoAsyncWorkID)(cData)
-	asyncWorkData := instanceData.GetAsyncWorkData().GetAsyncWork(id)
-	asyncWorkData.Execute(env)
+	asyncWorkData.Err = asyncWorkData.Execute(env)
 }
 
 //export ExecuteAsyncCompleteCallback
@@ -239,45 +363,128 @@ func ExecuteAsyncCompleteCallback(
 	cData unsafe.Pointer,
 ) {
 	env := Env(cEnv)
+	if debug {
+		debugOpenScope(env)
+		defer debugCloseScope(env)
+	}
 	defer func() {
 		err := recover()
 		if err != nil {
-			fmt.Printf(
-				"napi.ExecuteAsyncExecuteCallback: Recovered from panic: %s\n",
-				err,
-			)
-			reportStackTrace()
+			HandlePanic(env, "napi.ExecuteAsyncCompleteCallback", err)
+		}
+	}()
 
-			msg := "unknown error"
-			if err, ok := err.(error); ok {
-				msg = err.Error()
-			}
-			ThrowError(env, "", msg)
+	asyncWorkData := asyncWorkEntry(cData)
+	if asyncWorkData == nil {
+		return
+	}
+	asyncWorkData.Complete(env, Status(cStatus), asyncWorkData.Err)
+}
+
+//export ExecuteFinalizeCallback
+func ExecuteFinalizeCallback(
+	cEnv C.napi_env,
+	finalizeData, finalizeHint unsafe.Pointer,
+) {
+	env := Env(cEnv)
+	defer func() {
+		err := recover()
+		if err != nil {
+			HandlePanic(nil, "napi.ExecuteFinalizeCallback", err)
 		}
 	}()
 
 	instanceData, status := getInstanceData(env)
 	if status != StatusOK {
-		panic(StatusError(status))
+		panic(NewError(env, "GetInstanceData", status))
 	}
 
-	id := *(*NapiGoAsyncWorkID)(cData)
-	asyncWorkData := instanceData.GetAsyncWorkData().GetAsyncWork(id)
-	asyncWorkData.Complete(env, Status(cStatus))
+	id := *(*NapiGoFinalizerID)(finalizeHint)
+	finalizerData := instanceData.GetFinalizerData()
+	finalizer := finalizerData.GetFinalizer(id)
+	finalizerData.DeleteFinalizer(id)
+	finalizer.Finalize(env, finalizeData, finalizer.Hint)
 }
 
-func getInstanceDataHandle(env Env) (cgo.Handle, Status) {
+//export ExecuteEnvCleanupHook
+func ExecuteEnvCleanupHook(arg unsafe.Pointer) {
+	defer func() {
+		err := recover()
+		if err != nil {
+			HandlePanic(nil, "napi.ExecuteEnvCleanupHook", err)
+		}
+	}()
+
+	id := *(*NapiGoCleanupHookID)(arg)
+	hook := cleanupHookData.GetCleanupHook(id)
+	cleanupHookData.DeleteCleanupHook(id)
+	hook.Hook()
+}
+
+//export DeleteWrapHandle
+func DeleteWrapHandle(handle C.uintptr_t) {
+	cgo.Handle(handle).Delete()
+}
+
+// createFinalizer registers finalize with the instance data of env and
+// returns the C callback and hint to pass to a napi function accepting a
+// napi_finalize. A nil finalize yields a nil callback. If the napi call fails,
+// the caller must release the registration with deleteFinalizer.
+func createFinalizer(
+	env Env,
+	finalize Finalize,
+	hint unsafe.Pointer,
+) (C.napi_finalize, *NapiGoFinalizerMapEntry, Status) {
+	if finalize == nil {
+		return nil, nil, StatusOK
+	}
+
+	provider, status := getInstanceData(env)
+	if status != StatusOK || provider == nil {
+		return nil, nil, status
+	}
+
+	entry := provider.GetFinalizerData().CreateFinalizer(finalize, hint)
+	return C.napi_finalize(C.ExecuteFinalizeCallback), entry, status
+}
+
+func finalizerHint(entry *NapiGoFinalizerMapEntry) unsafe.Pointer {
+	if entry == nil {
+		return nil
+	}
+
+	return entry.token
+}
+
+func deleteFinalizer(env Env, entry *NapiGoFinalizerMapEntry) {
+	if entry == nil {
+		return
+	}
+
+	provider, status := getInstanceData(env)
+	if status != StatusOK || provider == nil {
+		return
+	}
+
+	provider.GetFinalizerData().DeleteFinalizer(entry.ID)
+}
+
+func getInstanceDataToken(env Env) (unsafe.Pointer, Status) {
 	var result unsafe.Pointer
 	status := Status(C.napi_get_instance_data(
 		C.napi_env(env),
 		&result,
 	))
+	return result, status
+}
 
-	if status != StatusOK || result == nil {
+func getInstanceDataHandle(env Env) (cgo.Handle, Status) {
+	token, status := getInstanceDataToken(env)
+	if status != StatusOK || token == nil {
 		return cgo.Handle(0), status
 	}
 
-	return cgo.Handle(result), status
+	return *(*cgo.Handle)(token), status
 }
 
 func getInstanceData(env Env) (InstanceDataProvider, Status) {
@@ -292,28 +499,29 @@ func getInstanceData(env Env) (InstanceDataProvider, Status) {
 func setInstanceData(env Env, data *NapiGoInstanceData) Status {
 	// check if an existing handle is already set, and clean it up if so
 	// (napi won't invoke the finalizer if overwriting instance data)
-	handle, status := getInstanceDataHandle(env)
+	token, status := getInstanceDataToken(env)
 	if status != StatusOK {
 		return status
 	}
 
-	if handle != 0 {
-		handle.Delete()
+	if token != nil {
+		(*(*cgo.Handle)(token)).Delete()
+		freeToken(token)
 	}
 
-	dataHandle := cgo.NewHandle(data)
-	return Status(C.napi_set_instance_data(
+	dataToken := newToken(cgo.NewHandle(data))
+	status = Status(C.napi_set_instance_data(
 		C.napi_env(env),
-		unsafe.Pointer(dataHandle),
+		dataToken,
 		C.napi_finalize(C.DeleteInstanceData),
 		nil,
 	))
-}
 
-func reportStackTrace() {
-	stackTraceBuf := make([]byte, maxStackTraceSize)
-	stackTraceSz := runtime.Stack(stackTraceBuf, false)
-	fmt.Printf("%s\n", string(stackTraceBuf[:stackTraceSz]))
+	if status != StatusOK {
+		(*(*cgo.Handle)(dataToken)).Delete()
+		freeToken(dataToken)
+	}
+	return status
 }
 
 func (d *NapiGoInstanceData) GetUserData() any {
@@ -332,6 +540,10 @@ func (d *NapiGoInstanceData) GetAsyncWorkData() AsyncWorkDataProvider {
 	return &d.AsyncWorkData
 }
 
+func (d *NapiGoInstanceData) GetFinalizerData() FinalizerDataProvider {
+	return &d.FinalizerData
+}
+
 func (d *NapiGoInstanceCallbackData) CreateCallback(
 	env Env,
 	name string,
@@ -350,8 +562,8 @@ func (d *NapiGoInstanceCallbackData) CreateCallback(
 		C.napi_env(env),
 		cname,
 		C.size_t(len([]byte(name))),
-		C.napi_callback(C.ExecuteCallback),
-		unsafe.Pointer(&callbackState.ID),
+		C.napi_callback(C.napi_go_execute_callback),
+		callbackState.token,
 		(*C.napi_value)(unsafe.Pointer(&result)),
 	))
 
@@ -359,16 +571,181 @@ func (d *NapiGoInstanceCallbackData) CreateCallback(
 		status = Status(C.napi_add_finalizer(
 			C.napi_env(env),
 			C.napi_value(result),
-			unsafe.Pointer(&callbackState.ID),
+			callbackState.token,
 			C.napi_finalize(C.DeleteCallbackData),
 			nil,
 			nil,
 		))
 	}
 
+	if status != StatusOK && result == nil {
+		delete(d.CallbackMap, callbackState.ID)
+		(*(*cgo.Handle)(callbackState.token)).Delete()
+		freeToken(callbackState.token)
+	}
 	return result, status
 }
 
+// DefineProperties defines properties on object, registering the callbacks
+// of methods and accessors for as long as object is alive.
+func (d *NapiGoInstanceCallbackData) DefineProperties(
+	env Env,
+	object Value,
+	properties []PropertyDescriptor,
+) Status {
+	if len(properties) == 0 {
+		return StatusOK
+	}
+
+	descs, entries, free := d.propertyDescriptors(properties)
+	defer free()
+
+	status := Status(C.napi_define_properties(
+		C.napi_env(env),
+		C.napi_value(object),
+		C.size_t(len(properties)),
+		descs,
+	))
+	return d.attachEntries(env, object, entries, status)
+}
+
+// DefineClass defines a JS class with the given constructor and properties.
+// Properties with the Static attribute are defined on the class, the others
+// on its prototype.
+func (d *NapiGoInstanceCallbackData) DefineClass(
+	env Env,
+	name string,
+	constructor Callback,
+	properties []PropertyDescriptor,
+) (Value, Status) {
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+
+	d.Lock.Lock()
+	constructorState := d.insert(constructor)
+	d.Lock.Unlock()
+
+	descs, entries, free := d.propertyDescriptors(properties)
+	defer free()
+	entries = append(entries, constructorState)
+
+	var result Value
+	status := Status(C.napi_define_class(
+		C.napi_env(env),
+		cname,
+		C.size_t(len([]byte(name))),
+		C.napi_callback(C.napi_go_execute_callback),
+		constructorState.token,
+		C.size_t(len(properties)),
+		descs,
+		(*C.napi_value)(unsafe.Pointer(&result)),
+	))
+	return result, d.attachEntries(env, result, entries, status)
+}
+
+// propertyDescriptors converts properties to a C array, registering the
+// callbacks they reference. A descriptor with both a getter and a setter
+// shares a single entry, which dispatches on the number of arguments since
+// Node passes the same data to both.
+func (d *NapiGoInstanceCallbackData) propertyDescriptors(
+	properties []PropertyDescriptor,
+) (*C.napi_property_descriptor, []*NapiGoCallbackMapEntry, func()) {
+	if len(properties) == 0 {
+		return nil, nil, func() {}
+	}
+
+	descs := (*C.napi_property_descriptor)(C.calloc(
+		C.size_t(len(properties)),
+		C.sizeof_napi_property_descriptor,
+	))
+	cdescs := unsafe.Slice(descs, len(properties))
+
+	d.Lock.Lock()
+	defer d.Lock.Unlock()
+
+	var entries []*NapiGoCallbackMapEntry
+	for i, p := range properties {
+		desc := &cdescs[i]
+		if p.Utf8name != "" {
+			desc.utf8name = C.CString(p.Utf8name)
+		}
+		desc.name = C.napi_value(p.Name)
+		desc.value = C.napi_value(p.Value)
+		desc.attributes = C.napi_property_attributes(p.Attributes)
+		desc.data = p.Data
+
+		var cb Callback
+		switch {
+		case p.Method != nil:
+			cb = p.Method
+			desc.method = C.napi_callback(C.napi_go_execute_callback)
+		case p.Getter != nil || p.Setter != nil:
+			cb = accessor(p.Getter, p.Setter)
+			if p.Getter != nil {
+				desc.getter = C.napi_callback(C.napi_go_execute_callback)
+			}
+			if p.Setter != nil {
+				desc.setter = C.napi_callback(C.napi_go_execute_callback)
+			}
+		default:
+			continue
+		}
+
+		entry := d.insert(cb)
+		desc.data = entry.token
+		entries = append(entries, entry)
+	}
+
+	return descs, entries, func() {
+		for _, desc := range cdescs {
+			C.free(unsafe.Pointer(desc.utf8name))
+		}
+		C.free(unsafe.Pointer(descs))
+	}
+}
+
+// attachEntries ties the lifetime of entries to target if status is
+// StatusOK, and deletes them otherwise.
+func (d *NapiGoInstanceCallbackData) attachEntries(
+	env Env,
+	target Value,
+	entries []*NapiGoCallbackMapEntry,
+	status Status,
+) Status {
+	for i, entry := range entries {
+		if status == StatusOK {
+			status = Status(C.napi_add_finalizer(
+				C.napi_env(env),
+				C.napi_value(target),
+				entry.token,
+				C.napi_finalize(C.DeleteCallbackData),
+				nil,
+				nil,
+			))
+			if status == StatusOK {
+				continue
+			}
+		}
+
+		for _, entry := range entries[i:] {
+			d.DeleteCallback(entry.ID)
+		}
+		return status
+	}
+	return status
+}
+
+// accessor returns a callback that calls getter when invoked without
+// arguments and setter otherwise.
+func accessor(getter, setter Callback) Callback {
+	return func(env Env, info CallbackInfo) Value {
+		if len(info.Args) == 0 {
+			return getter(env, info)
+		}
+		return setter(env, info)
+	}
+}
+
 func (d *NapiGoInstanceCallbackData) GetCallback(
 	id NapiGoCallbackID,
 ) *NapiGoCallbackMapEntry {
@@ -380,6 +757,11 @@ func (d *NapiGoInstanceCallbackData) GetCallback(
 func (d *NapiGoInstanceCallbackData) DeleteCallback(id NapiGoCallbackID) {
 	d.Lock.Lock()
 	defer d.Lock.Unlock()
+
+	if entry := d.CallbackMap[id]; entry != nil {
+		(*(*cgo.Handle)(entry.token)).Delete()
+		freeToken(entry.token)
+	}
 	delete(d.CallbackMap, id)
 }
 
@@ -401,6 +783,7 @@ func (d *NapiGoInstanceCallbackData) insert(
 				Callback: cb,
 				ID:       id,
 			}
+			result.token = newToken(cgo.NewHandle(result))
 			d.CallbackMap[id] = result
 			return result
 		}
@@ -414,9 +797,8 @@ func (d *NapiGoInstanceAsyncWorkData) CreateAsyncWork(
 	complete AsyncCompleteCallback,
 ) (AsyncWork, Status) {
 	d.Lock.Lock()
-	defer d.Lock.Unlock()
-
 	asyncWorkState := d.insert(execute, complete)
+	d.Lock.Unlock()
 
 	result := AsyncWork{
 		ID: asyncWorkState.ID,
@@ -427,10 +809,13 @@ func (d *NapiGoInstanceAsyncWorkData) CreateAsyncWork(
 		C.napi_value(asyncResourceName),
 		C.napi_async_execute_callback(C.ExecuteAsyncExecuteCallback),
 		C.napi_async_complete_callback(C.ExecuteAsyncCompleteCallback),
-		unsafe.Pointer(&asyncWorkState.ID),
+		asyncWorkState.token,
 		(*C.napi_async_work)(unsafe.Pointer(&result.Handle)),
 	))
 
+	if status != StatusOK {
+		d.DeleteAsyncWork(asyncWorkState.ID)
+	}
 	return result, status
 }
 
@@ -445,6 +830,11 @@ func (d *NapiGoInstanceAsyncWorkData) GetAsyncWork(
 func (d *NapiGoInstanceAsyncWorkData) DeleteAsyncWork(id NapiGoAsyncWorkID) {
 	d.Lock.Lock()
 	defer d.Lock.Unlock()
+
+	if entry := d.AsyncWorkMap[id]; entry != nil {
+		(*(*cgo.Handle)(entry.token)).Delete()
+		freeToken(entry.token)
+	}
 	delete(d.AsyncWorkMap, id)
 }
 
@@ -468,8 +858,99 @@ func (d *NapiGoInstanceAsyncWorkData) insert(
 				Complete: complete,
 				ID:       id,
 			}
+			result.token = newToken(cgo.NewHandle(result))
 			d.AsyncWorkMap[id] = result
 			return result
 		}
 	}
 }
+
+func (d *NapiGoInstanceFinalizerData) CreateFinalizer(
+	finalize Finalize,
+	hint unsafe.Pointer,
+) *NapiGoFinalizerMapEntry {
+	d.Lock.Lock()
+	defer d.Lock.Unlock()
+
+	if d.FinalizerMap == nil {
+		d.FinalizerMap = NapiGoInstanceFinalizerMap{}
+	}
+
+	for {
+		id := d.NextID
+		d.NextID++
+
+		if d.FinalizerMap[id] == nil {
+			result := &NapiGoFinalizerMapEntry{
+				Finalize: finalize,
+				Hint:     hint,
+				ID:       id,
+				token:    newToken(id),
+			}
+			d.FinalizerMap[id] = result
+			return result
+		}
+	}
+}
+
+func (d *NapiGoInstanceFinalizerData) GetFinalizer(
+	id NapiGoFinalizerID,
+) *NapiGoFinalizerMapEntry {
+	d.Lock.RLock()
+	defer d.Lock.RUnlock()
+	return d.FinalizerMap[id]
+}
+
+func (d *NapiGoInstanceFinalizerData) DeleteFinalizer(id NapiGoFinalizerID) {
+	d.Lock.Lock()
+	defer d.Lock.Unlock()
+
+	if entry := d.FinalizerMap[id]; entry != nil {
+		freeToken(entry.token)
+	}
+	delete(d.FinalizerMap, id)
+}
+
+func (d *NapiGoCleanupHookData) CreateCleanupHook(
+	hook func(),
+) *NapiGoCleanupHookMapEntry {
+	d.Lock.Lock()
+	defer d.Lock.Unlock()
+
+	if d.CleanupHookMap == nil {
+		d.CleanupHookMap = NapiGoCleanupHookMap{}
+	}
+
+	for {
+		id := d.NextID
+		d.NextID++
+
+		if d.CleanupHookMap[id] == nil {
+			result := &NapiGoCleanupHookMapEntry{
+				Hook:  hook,
+				ID:    id,
+				token: newToken(id),
+			}
+			d.CleanupHookMap[id] = result
+			return result
+		}
+	}
+}
+
+func (d *NapiGoCleanupHookData) GetCleanupHook(
+	id NapiGoCleanupHookID,
+) *NapiGoCleanupHookMapEntry {
+	d.Lock.RLock()
+	defer d.Lock.RUnlock()
+	return d.CleanupHookMap[id]
+}
+
+func (d *NapiGoCleanupHookData) DeleteCleanupHook(id NapiGoCleanupHookID) {
+	d.Lock.Lock()
+	defer d.Lock.Unlock()
+
+	if entry := d.CleanupHookMap[id]; entry != nil {
+		freeToken(entry.token)
+	}
+	delete(d.CleanupHookMap, id)
+}
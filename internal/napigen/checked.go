@@ -0,0 +1,186 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Checked returns a file declaring a method of the type Checked for every
+// exported function of the package in dir that takes an Env first and returns
+// a Status last. The method calls the function with the Env of its receiver,
+// and returns the Status as an error made by NewError. skip is the name of
+// the file to write, which is ignored.
+func Checked(dir, skip string) ([]byte, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		methods []string
+		names   []string
+		imports = map[string]string{}
+	)
+	fset := token.NewFileSet()
+	for _, path := range paths {
+		base := filepath.Base(path)
+		if base == filepath.Base(skip) || strings.HasSuffix(base, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		fileImports := map[string]string{}
+		for _, imp := range f.Imports {
+			p, _ := strconv.Unquote(imp.Path.Value)
+			name := p[strings.LastIndex(p, "/")+1:]
+			if imp.Name != nil {
+				name = imp.Name.Name
+			}
+			fileImports[name] = p
+		}
+
+		for _, decl := range f.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv != nil || !fn.Name.IsExported() || !isChecked(fn.Type) {
+				continue
+			}
+			src, qualifiers := checkedMethod(fset, fn)
+			for _, q := range qualifiers {
+				imports[q] = fileImports[q]
+			}
+			methods = append(methods, src)
+			names = append(names, fn.Name.Name)
+		}
+	}
+	sort.Sort(byName{names, methods})
+
+	var w bytes.Buffer
+	w.WriteString("// Code generated by napigen. DO NOT EDIT.\n\npackage napi\n")
+	if len(imports) > 0 {
+		paths := make([]string, 0, len(imports))
+		for _, p := range imports {
+			paths = append(paths, strconv.Quote(p))
+		}
+		sort.Strings(paths)
+		fmt.Fprintf(&w, "\nimport (\n%s\n)\n", strings.Join(paths, "\n"))
+	}
+	w.WriteString("\n" + strings.Join(methods, "\n"))
+	return format.Source(w.Bytes())
+}
+
+// isChecked reports whether a function of type t has a method in Checked.
+func isChecked(t *ast.FuncType) bool {
+	if len(t.Params.List) == 0 || t.Results == nil || len(t.Results.List) == 0 {
+		return false
+	}
+	if env, ok := t.Params.List[0].Type.(*ast.Ident); !ok || env.Name != "Env" {
+		return false
+	}
+	last := t.Results.List[len(t.Results.List)-1]
+	if status, ok := last.Type.(*ast.Ident); !ok || status.Name != "Status" || len(last.Names) > 1 {
+		return false
+	}
+
+	// functions using cgo types are not meant to be called from Go
+	cgo := false
+	ast.Inspect(t, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if x, ok := sel.X.(*ast.Ident); ok && x.Name == "C" {
+				cgo = true
+			}
+		}
+		return !cgo
+	})
+	return !cgo
+}
+
+// checkedMethod returns the method of Checked for fn, along with the package
+// qualifiers used by its signature.
+func checkedMethod(fset *token.FileSet, fn *ast.FuncDecl) (string, []string) {
+	var qualifiers []string
+	typeString := func(expr ast.Expr) string {
+		ast.Inspect(expr, func(n ast.Node) bool {
+			if sel, ok := n.(*ast.SelectorExpr); ok {
+				if x, ok := sel.X.(*ast.Ident); ok {
+					qualifiers = append(qualifiers, x.Name)
+				}
+			}
+			return true
+		})
+		var b strings.Builder
+		printer.Fprint(&b, fset, expr)
+		return b.String()
+	}
+
+	var params, args []string
+	for i, field := range fn.Type.Params.List {
+		names := field.Names
+		if i == 0 {
+			names = names[1:]
+		}
+		if len(names) == 0 {
+			continue
+		}
+
+		var ids []string
+		for _, name := range names {
+			ids = append(ids, name.Name)
+			args = append(args, name.Name)
+		}
+		if _, ok := field.Type.(*ast.Ellipsis); ok {
+			args[len(args)-1] += "..."
+		}
+		params = append(params, strings.Join(ids, ", ")+" "+typeString(field.Type))
+	}
+	call := fmt.Sprintf("%s(%s)", fn.Name.Name, strings.Join(append([]string{"c.Env"}, args...), ", "))
+
+	var results, vars []string
+	for _, field := range fn.Type.Results.List[:len(fn.Type.Results.List)-1] {
+		n := len(field.Names)
+		if n == 0 {
+			n = 1
+		}
+		for j := 0; j < n; j++ {
+			results = append(results, typeString(field.Type))
+			vars = append(vars, fmt.Sprintf("r%d", len(vars)))
+		}
+	}
+
+	var w strings.Builder
+	fmt.Fprintf(&w, "func (c Checked) %s(%s) ", fn.Name.Name, strings.Join(params, ", "))
+	if len(results) == 0 {
+		w.WriteString("error {\n")
+		fmt.Fprintf(&w, "status := %s\n", call)
+		fmt.Fprintf(&w, "return NewError(c.Env, %q, status)\n", fn.Name.Name)
+	} else {
+		fmt.Fprintf(&w, "(%s, error) {\n", strings.Join(results, ", "))
+		fmt.Fprintf(&w, "%s, status := %s\n", strings.Join(vars, ", "), call)
+		fmt.Fprintf(&w, "return %s, NewError(c.Env, %q, status)\n", strings.Join(vars, ", "), fn.Name.Name)
+	}
+	w.WriteString("}\n")
+	return w.String(), qualifiers
+}
+
+type byName struct {
+	names   []string
+	methods []string
+}
+
+func (s byName) Len() int           { return len(s.names) }
+func (s byName) Less(i, j int) bool { return s.names[i] < s.names[j] }
+func (s byName) Swap(i, j int) {
+	s.names[i], s.names[j] = s.names[j], s.names[i]
+	s.methods[i], s.methods[j] = s.methods[j], s.methods[i]
+}
@@ -0,0 +1,220 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strings"
+)
+
+// inTypes maps C parameter types to Go types and the conversion of a Go
+// argument to C.
+var inTypes = map[string]struct{ goType, conv string }{
+	"napi_env":           {"Env", "C.napi_env(%s)"},
+	"node_api_basic_env": {"Env", "C.node_api_basic_env(%s)"},
+	"napi_value":         {"Value", "C.napi_value(%s)"},
+	"bool":               {"bool", "C.bool(%s)"},
+	"int32_t":            {"int32", "C.int32_t(%s)"},
+	"uint32_t":           {"uint32", "C.uint32_t(%s)"},
+	"int64_t":            {"int64", "C.int64_t(%s)"},
+	"uint64_t":           {"uint64", "C.uint64_t(%s)"},
+	"double":             {"float64", "C.double(%s)"},
+	"size_t":             {"int", "C.size_t(%s)"},
+	"void*":              {"unsafe.Pointer", "%s"},
+}
+
+// outTypes maps the C types of out-parameters to Go types and the C type of
+// the variable they are read from.
+var outTypes = map[string]struct{ goType, cType string }{
+	"napi_value*": {"Value", ""},
+	"bool*":       {"bool", "C.bool"},
+	"int32_t*":    {"int32", "C.int32_t"},
+	"uint32_t*":   {"uint32", "C.uint32_t"},
+	"int64_t*":    {"int64", "C.int64_t"},
+	"uint64_t*":   {"uint64", "C.uint64_t"},
+	"double*":     {"float64", "C.double"},
+	"size_t*":     {"int", "C.size_t"},
+	"void**":      {"unsafe.Pointer", ""},
+}
+
+// words fixes the case of words in Go names that are not simply capitalized.
+var words = map[string]string{
+	"arraybuffer": "ArrayBuffer",
+	"bigint":      "BigInt",
+	"dataview":    "DataView",
+	"instanceof":  "InstanceOf",
+	"typedarray":  "TypedArray",
+	"typeof":      "TypeOf",
+	"uv":          "UV",
+}
+
+// GoName returns the name of the Go binding of the C function name.
+func GoName(name string) string {
+	name = strings.TrimPrefix(name, "napi_")
+	name = strings.TrimPrefix(name, "node_api_")
+
+	var b strings.Builder
+	for _, w := range strings.Split(name, "_") {
+		if fixed, ok := words[w]; ok {
+			b.WriteString(fixed)
+		} else if w != "" {
+			b.WriteString(strings.ToUpper(w[:1]) + w[1:])
+		}
+	}
+	return b.String()
+}
+
+// binding is the Go source of a generated binding.
+type binding struct {
+	env     string
+	checks  []string
+	params  []string
+	setup   []string
+	args    []string
+	results []string
+	vars    []string
+	returns []string
+}
+
+// Generate returns the Go binding of fn, or false if fn has parameters that
+// cannot be mapped automatically and needs a hand-written binding.
+func Generate(fn Function) (string, bool) {
+	if fn.Return != "napi_status" || len(fn.Params) == 0 {
+		return "", false
+	}
+
+	var b binding
+	params := fn.Params
+	for i := 0; i < len(params); i++ {
+		p := params[i]
+		name := goIdent(p.Name)
+
+		// A string is passed with its length in the next parameter.
+		if p.Type == "const char*" {
+			b.params = append(b.params, name+" string")
+			cname := "c" + strings.ToUpper(name[:1]) + name[1:]
+			b.setup = append(b.setup,
+				fmt.Sprintf("%s := C.CString(%s)", cname, name),
+				fmt.Sprintf("defer C.free(unsafe.Pointer(%s))", cname),
+			)
+			b.args = append(b.args, cname)
+			if i+1 < len(params) && params[i+1].Type == "size_t" {
+				b.args = append(b.args, fmt.Sprintf("C.size_t(len(%s))", name))
+				i++
+			}
+			continue
+		}
+
+		if in, ok := inTypes[p.Type]; ok {
+			switch in.goType {
+			case "Env":
+				b.env = name
+			case "Value":
+				b.checks = append(b.checks, name)
+			}
+			b.params = append(b.params, name+" "+in.goType)
+			b.args = append(b.args, fmt.Sprintf(in.conv, name))
+			continue
+		}
+
+		out, ok := outTypes[p.Type]
+		if !ok {
+			return "", false
+		}
+		b.results = append(b.results, out.goType)
+		switch {
+		case out.goType == "Value":
+			b.vars = append(b.vars, fmt.Sprintf("var %s Value", name))
+			b.args = append(b.args, fmt.Sprintf("(*C.napi_value)(unsafe.Pointer(&%s))", name))
+			b.returns = append(b.returns, name)
+		case out.cType == "":
+			b.vars = append(b.vars, fmt.Sprintf("var %s %s", name, out.goType))
+			b.args = append(b.args, "&"+name)
+			b.returns = append(b.returns, name)
+		default:
+			b.vars = append(b.vars, fmt.Sprintf("var %s %s", name, out.cType))
+			b.args = append(b.args, "&"+name)
+			b.returns = append(b.returns, fmt.Sprintf("%s(%s)", out.goType, name))
+		}
+	}
+
+	var w bytes.Buffer
+	fmt.Fprintf(&w, "func %s(%s) ", GoName(fn.Name), strings.Join(b.params, ", "))
+	if len(b.results) == 0 {
+		w.WriteString("Status {\n")
+	} else {
+		fmt.Fprintf(&w, "(%s, Status) {\n", strings.Join(b.results, ", "))
+	}
+	// the debug hooks of debug.go validate every call in napidebug builds
+	if b.env != "" {
+		args := append([]string{b.env, fmt.Sprintf("%q", GoName(fn.Name))}, b.checks...)
+		fmt.Fprintf(&w, "debugCall(%s)\n", strings.Join(args, ", "))
+	}
+	for _, line := range b.setup {
+		w.WriteString(line + "\n")
+	}
+	if len(b.setup) > 0 {
+		w.WriteString("\n")
+	}
+	for _, line := range b.vars {
+		w.WriteString(line + "\n")
+	}
+	if len(b.results) == 0 {
+		w.WriteString("return ")
+	} else {
+		w.WriteString("status := ")
+	}
+	fmt.Fprintf(&w, "Status(C.%s(\n", fn.Name)
+	for _, arg := range b.args {
+		w.WriteString(arg + ",\n")
+	}
+	w.WriteString("))\n")
+	if b.env != "" {
+		for i, result := range b.results {
+			if result == "Value" {
+				fmt.Fprintf(&w, "debugResult(%s, %s)\n", b.env, b.returns[i])
+			}
+		}
+	}
+	if len(b.results) > 0 {
+		fmt.Fprintf(&w, "return %s, status\n", strings.Join(b.returns, ", "))
+	}
+	w.WriteString("}\n")
+	return w.String(), true
+}
+
+// File returns the formatted source of a Go file with the given bindings.
+func File(bindings []string) ([]byte, error) {
+	body := strings.Join(bindings, "\n")
+
+	var w bytes.Buffer
+	w.WriteString("// Code generated by napigen. DO NOT EDIT.\n\npackage napi\n\n/*\n")
+	if strings.Contains(body, "C.free(") {
+		w.WriteString("#include <stdlib.h>\n")
+	}
+	w.WriteString("#include <node/node_api.h>\n*/\nimport \"C\"\n")
+	if strings.Contains(body, "unsafe.") {
+		w.WriteString("\nimport \"unsafe\"\n")
+	}
+	w.WriteString("\n" + body)
+	return format.Source(w.Bytes())
+}
+
+// goIdent returns a Go identifier for the C parameter name.
+func goIdent(name string) string {
+	var b strings.Builder
+	for i, w := range strings.Split(name, "_") {
+		if i > 0 && w != "" {
+			w = strings.ToUpper(w[:1]) + w[1:]
+		}
+		b.WriteString(w)
+	}
+	switch s := b.String(); s {
+	case "type", "func", "range", "string", "len":
+		return s + "_"
+	case "":
+		return "arg"
+	default:
+		return s
+	}
+}
@@ -0,0 +1,193 @@
+// Command napigen generates Go bindings for the Node-API functions that the
+// napi package does not bind by hand, and reports which functions are bound.
+//
+// It parses js_native_api.h and node_api.h, skips every function that is
+// already called from a Go file of the package, and writes bindings for the
+// rest whose parameters map directly to Go. Functions taking callbacks,
+// structs or other handles are listed as missing and need hand-written
+// bindings. Only functions called by exported Go functions count as bound;
+// the ones the package only uses internally are listed as missing too.
+//
+// With -checked, it also writes the methods of napi.Checked, which wrap the
+// exported functions taking an Env and return an error instead of a Status.
+//
+// Usage:
+//
+//	go run ./internal/napigen [-include dir] [-out file] [-checked file] [-coverage]
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	includeDir = flag.String("include", "/usr/include/node", "directory containing the Node-API headers")
+	pkgDir     = flag.String("pkg", ".", "directory of the napi package")
+	outFile    = flag.String("out", "", "file to write generated bindings to, relative to -pkg")
+	checkedOut = flag.String("checked", "", "file to write the methods of Checked to, relative to -pkg")
+	coverage   = flag.Bool("coverage", false, "print Node-API coverage by NAPI_VERSION")
+)
+
+// unbound lists functions that are deliberately not bound, with the reason.
+var unbound = map[string]string{
+	"napi_module_register":   "modules are registered by the entry package",
+	"napi_get_instance_data": "instance data holds the registries of the napi package",
+	"napi_set_instance_data": "instance data holds the registries of the napi package",
+}
+
+var callRe = regexp.MustCompile(`\b((?:napi|node_api)_\w+)\s*\(`)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("napigen: ")
+	flag.Parse()
+
+	var fns []Function
+	for _, header := range []string{"js_native_api.h", "node_api.h"} {
+		parsed, err := ParseHeader(filepath.Join(*includeDir, header))
+		if err != nil {
+			log.Fatal(err)
+		}
+		fns = append(fns, parsed...)
+	}
+
+	pkg, err := scanPackage(*pkgDir, *outFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var (
+		bindings  []string
+		generated = map[string]bool{}
+	)
+	for _, fn := range fns {
+		if pkg.Called[fn.Name] || pkg.Declared[GoName(fn.Name)] {
+			continue
+		}
+		if src, ok := Generate(fn); ok {
+			bindings = append(bindings, src)
+			generated[fn.Name] = true
+		}
+	}
+
+	if *outFile != "" {
+		src, err := File(bindings)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = os.WriteFile(filepath.Join(*pkgDir, *outFile), src, 0o644)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if *checkedOut != "" {
+		// the methods cover the bindings generated above
+		src, err := Checked(*pkgDir, *checkedOut)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = os.WriteFile(filepath.Join(*pkgDir, *checkedOut), src, 0o644)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if *coverage {
+		report(fns, pkg, generated)
+	}
+}
+
+// versionCoverage is the coverage of the functions of one NAPI_VERSION.
+type versionCoverage struct {
+	Version int
+	Total   int
+	Bound   int
+	// Missing holds the functions that no exported Go function binds, along
+	// with the reason from unbound, if any.
+	Missing []missingFunction
+}
+
+type missingFunction struct {
+	Name   string
+	Reason string
+}
+
+func (m missingFunction) String() string {
+	if m.Reason == "" {
+		return m.Name
+	}
+	return fmt.Sprintf("%s (%s)", m.Name, m.Reason)
+}
+
+// computeCoverage returns the coverage of fns by NAPI_VERSION. A function is
+// bound when an exported Go function of pkg or a generated binding calls it.
+func computeCoverage(fns []Function, pkg *Package, generated map[string]bool) []versionCoverage {
+	byVersion := map[int]*versionCoverage{}
+	for _, fn := range fns {
+		c := byVersion[fn.Version]
+		if c == nil {
+			c = &versionCoverage{Version: fn.Version}
+			byVersion[fn.Version] = c
+		}
+
+		c.Total++
+		switch {
+		case pkg.Exported[fn.Name] || generated[fn.Name]:
+			c.Bound++
+		case unbound[fn.Name] != "":
+			c.Missing = append(c.Missing, missingFunction{fn.Name, unbound[fn.Name]})
+		case pkg.Called[fn.Name]:
+			c.Missing = append(c.Missing, missingFunction{fn.Name, "internal only"})
+		default:
+			c.Missing = append(c.Missing, missingFunction{Name: fn.Name})
+		}
+	}
+
+	result := make([]versionCoverage, 0, len(byVersion))
+	for _, c := range byVersion {
+		result = append(result, *c)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Version < result[j].Version })
+	return result
+}
+
+// versionName returns the name of the NAPI_VERSION v.
+func versionName(v int) string {
+	if v == Experimental {
+		return "NAPI_EXPERIMENTAL"
+	}
+	return fmt.Sprintf("NAPI_VERSION %d", v)
+}
+
+// report prints the bound functions of each NAPI_VERSION and lists the ones
+// that are missing.
+func report(fns []Function, pkg *Package, generated map[string]bool) {
+	total, bound := 0, 0
+	for _, c := range computeCoverage(fns, pkg, generated) {
+		total += c.Total
+		bound += c.Bound
+
+		fmt.Printf("%-18s %3d/%-3d\n", versionName(c.Version), c.Bound, c.Total)
+		for _, m := range c.Missing {
+			fmt.Printf("    missing %s\n", m)
+		}
+	}
+	fmt.Printf("%-18s %3d/%-3d\n", "total", bound, total)
+
+	if len(generated) > 0 {
+		names := make([]string, 0, len(generated))
+		for name := range generated {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		fmt.Printf("generated: %s\n", strings.Join(names, " "))
+	}
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestCoverage checks that every Node-API function of the installed headers
+// is bound by an exported function of the napi package, or deliberately
+// listed in unbound.
+func TestCoverage(t *testing.T) {
+	var fns []Function
+	for _, header := range []string{"js_native_api.h", "node_api.h"} {
+		path := filepath.Join(*includeDir, header)
+		if _, err := os.Stat(path); err != nil {
+			t.Skipf("Node-API headers not found: %v", err)
+		}
+		parsed, err := ParseHeader(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fns = append(fns, parsed...)
+	}
+
+	pkg, err := scanPackage(filepath.Join("..", ".."), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range computeCoverage(fns, pkg, nil) {
+		t.Run(versionName(c.Version), func(t *testing.T) {
+			t.Logf("%d/%d bound", c.Bound, c.Total)
+			for _, m := range c.Missing {
+				if unbound[m.Name] == "" {
+					t.Errorf("missing %s", m)
+				}
+			}
+		})
+	}
+}
+
+func TestScanPackageExported(t *testing.T) {
+	pkg, err := scanPackage(filepath.Join("..", ".."), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		exported bool
+	}{
+		{"napi_add_finalizer", true},
+		// called through the napi_go_execute_callback trampoline
+		{"napi_get_cb_info", true},
+		{"napi_get_instance_data", false},
+	}
+	for _, tt := range tests {
+		if !pkg.Called[tt.name] {
+			t.Errorf("%s is not called", tt.name)
+		}
+		if pkg.Exported[tt.name] != tt.exported {
+			t.Errorf("Exported[%s] = %v, want %v", tt.name, pkg.Exported[tt.name], tt.exported)
+		}
+	}
+}
+
+func TestCheckedUpToDate(t *testing.T) {
+	want, err := Checked(filepath.Join("..", ".."), "zz_checked.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile(filepath.Join("..", "..", "zz_checked.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Error("zz_checked.go is out of date, run go generate")
+	}
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"bufio"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Experimental is the version of functions that are only declared with
+// NAPI_EXPERIMENTAL.
+const Experimental = 1 << 30
+
+// Function is a Node-API function declared in a header.
+type Function struct {
+	Name    string
+	Return  string
+	Params  []Param
+	Version int
+	Header  string
+}
+
+// Param is a parameter of a Function.
+type Param struct {
+	Type string
+	Name string
+}
+
+var (
+	commentRe   = regexp.MustCompile(`(?s)/\*.*?\*/|//[^\n]*`)
+	versionRe   = regexp.MustCompile(`^#\s*if\s+NAPI_VERSION\s*>=\s*(\d+)`)
+	declRe      = regexp.MustCompile(`(?s)^(?:NAPI_EXTERN|NAPI_NO_RETURN)\s+(.*?)\s*\bNAPI_CDECL\s+(\w+)\s*\((.*)\)\s*;$`)
+	spaceRe     = regexp.MustCompile(`\s+`)
+	paramNameRe = regexp.MustCompile(`^(.*?[\s*])(\w+)$`)
+)
+
+// ParseHeader returns the functions declared in the header at path, along
+// with the NAPI_VERSION that guards them.
+func ParseHeader(path string) ([]Function, error) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	text := commentRe.ReplaceAllString(string(src), "")
+
+	var (
+		result  []Function
+		guards  []int // version of each open #if, or 0
+		pending strings.Builder
+	)
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case strings.HasPrefix(line, "#"):
+			directive := strings.Join(strings.Fields(strings.TrimPrefix(line, "#")), " ")
+			switch {
+			case strings.HasPrefix(directive, "if"):
+				guard := 0
+				if m := versionRe.FindStringSubmatch(line); m != nil {
+					guard, _ = strconv.Atoi(m[1])
+				} else if directive == "ifdef NAPI_EXPERIMENTAL" ||
+					directive == "if defined(NAPI_EXPERIMENTAL)" {
+					guard = Experimental
+				}
+				guards = append(guards, guard)
+			case strings.HasPrefix(directive, "else"),
+				strings.HasPrefix(directive, "elif"):
+				if len(guards) > 0 {
+					guards[len(guards)-1] = 0
+				}
+			case strings.HasPrefix(directive, "endif"):
+				if len(guards) > 0 {
+					guards = guards[:len(guards)-1]
+				}
+			}
+			continue
+
+		case pending.Len() == 0 &&
+			!strings.HasPrefix(line, "NAPI_EXTERN") &&
+			!strings.HasPrefix(line, "NAPI_NO_RETURN"):
+			continue
+		}
+
+		pending.WriteString(line)
+		pending.WriteString(" ")
+		if !strings.HasSuffix(line, ";") {
+			continue
+		}
+
+		decl := strings.TrimSpace(spaceRe.ReplaceAllString(pending.String(), " "))
+		pending.Reset()
+
+		m := declRe.FindStringSubmatch(decl)
+		if m == nil {
+			continue
+		}
+
+		version := 1
+		for _, guard := range guards {
+			if guard > version {
+				version = guard
+			}
+		}
+
+		fn := Function{
+			Name:    m[2],
+			Return:  strings.TrimSpace(strings.ReplaceAll(m[1], "NAPI_EXTERN", "")),
+			Version: version,
+			Header:  path,
+		}
+		if params := strings.TrimSpace(m[3]); params != "" && params != "void" {
+			for _, p := range strings.Split(params, ",") {
+				fn.Params = append(fn.Params, parseParam(p))
+			}
+		}
+		result = append(result, fn)
+	}
+	return result, scanner.Err()
+}
+
+func parseParam(p string) Param {
+	p = strings.TrimSpace(p)
+	m := paramNameRe.FindStringSubmatch(p)
+	if m == nil {
+		return Param{Type: p}
+	}
+	typ := strings.ReplaceAll(strings.TrimSpace(m[1]), " *", "*")
+	return Param{Type: typ, Name: m[2]}
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Package is what scanPackage finds in the Go files of the napi package.
+type Package struct {
+	// Called holds the Node-API functions called anywhere in the package,
+	// including from cgo preambles.
+	Called map[string]bool
+	// Exported holds the Node-API functions called by exported Go functions
+	// and methods, either directly or through a C helper of a cgo preamble.
+	// Only these are available to users of the package.
+	Exported map[string]bool
+	// Declared holds the names of the top-level Go functions.
+	Declared map[string]bool
+}
+
+var helperRe = regexp.MustCompile(`\b(napi_go_\w+)\s*\([^;{}]*\)\s*\{`)
+
+// scanPackage scans the Go files in dir other than skip and their tests.
+func scanPackage(dir, skip string) (*Package, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+
+	pkg := &Package{
+		Called:   map[string]bool{},
+		Exported: map[string]bool{},
+		Declared: map[string]bool{},
+	}
+	helpers := map[string][]string{}
+	var exported [][]string // C names used by exported functions
+
+	fset := token.NewFileSet()
+	for _, path := range paths {
+		base := filepath.Base(path)
+		if (skip != "" && base == filepath.Base(skip)) || strings.HasSuffix(base, "_test.go") {
+			continue
+		}
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range callRe.FindAllStringSubmatch(string(src), -1) {
+			pkg.Called[m[1]] = true
+		}
+
+		f, err := parser.ParseFile(fset, path, src, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		for name, calls := range preambleHelpers(f) {
+			helpers[name] = calls
+		}
+		for _, decl := range f.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok {
+				continue
+			}
+			if fn.Recv == nil {
+				pkg.Declared[fn.Name.Name] = true
+			}
+			if isExported(fn) && fn.Body != nil {
+				exported = append(exported, cNames(fn.Body))
+			}
+		}
+	}
+
+	// helpers may be declared in other files than the functions using them
+	for _, names := range exported {
+		for _, name := range names {
+			markExported(pkg.Exported, helpers, name, map[string]bool{})
+		}
+	}
+	return pkg, nil
+}
+
+// markExported marks name, or the Node-API functions the helper name calls,
+// as exported.
+func markExported(exported map[string]bool, helpers map[string][]string, name string, seen map[string]bool) {
+	if !strings.HasPrefix(name, "napi_go_") {
+		exported[name] = true
+		return
+	}
+	if seen[name] {
+		return
+	}
+	seen[name] = true
+	for _, call := range helpers[name] {
+		markExported(exported, helpers, call, seen)
+	}
+}
+
+// isExported reports whether fn is an exported function, or an exported
+// method of an exported type.
+func isExported(fn *ast.FuncDecl) bool {
+	if !fn.Name.IsExported() {
+		return false
+	}
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return true
+	}
+
+	t := fn.Recv.List[0].Type
+	if star, ok := t.(*ast.StarExpr); ok {
+		t = star.X
+	}
+	ident, ok := t.(*ast.Ident)
+	return ok && ident.IsExported()
+}
+
+// cNames returns the names referenced as C.name in node.
+func cNames(node ast.Node) []string {
+	var result []string
+	ast.Inspect(node, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		if x, ok := sel.X.(*ast.Ident); ok && x.Name == "C" {
+			result = append(result, sel.Sel.Name)
+		}
+		return true
+	})
+	return result
+}
+
+// preambleHelpers returns the C functions named napi_go_* that are defined in
+// the cgo preamble of f, along with the functions each of them calls.
+func preambleHelpers(f *ast.File) map[string][]string {
+	var preamble string
+	for _, imp := range f.Imports {
+		if imp.Path.Value == `"C"` && imp.Doc != nil {
+			preamble = imp.Doc.Text()
+		}
+	}
+	for _, decl := range f.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if ok && gen.Tok == token.IMPORT && len(gen.Specs) == 1 && gen.Doc != nil {
+			if imp := gen.Specs[0].(*ast.ImportSpec); imp.Path.Value == `"C"` {
+				preamble = gen.Doc.Text()
+			}
+		}
+	}
+
+	result := map[string][]string{}
+	for _, loc := range helperRe.FindAllStringSubmatchIndex(preamble, -1) {
+		name := preamble[loc[2]:loc[3]]
+		body := preamble[loc[1]:]
+		depth := 1
+		for i, c := range body {
+			if c == '{' {
+				depth++
+			} else if c == '}' {
+				depth--
+			}
+			if depth == 0 {
+				body = body[:i]
+				break
+			}
+		}
+
+		var calls []string
+		for _, m := range callRe.FindAllStringSubmatch(body, -1) {
+			calls = append(calls, m[1])
+		}
+		result[name] = calls
+	}
+	return result
+}
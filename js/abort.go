@@ -0,0 +1,76 @@
+package js
+
+import (
+	"context"
+)
+
+// AsContext returns a context derived from parent that is cancelled when the
+// AbortSignal v aborts. The signal's reason, converted with AsError, is
+// reported by context.Cause. Calling the returned cancel function releases
+// the listener registered on v.
+func (v Value) AsContext(parent context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancelCause(parent)
+	if v.Get("aborted").Bool() {
+		cancel(abortReason(v))
+		return ctx, func() { cancel(context.Canceled) }
+	}
+
+	// aborting the controller removes the listener from the signal
+	controller := v.Env.Global().Get("AbortController").New()
+	v.Call(
+		"addEventListener",
+		"abort",
+		func(env Env, this Value, args []Value) any {
+			cancel(abortReason(this))
+			return nil
+		},
+		map[string]any{
+			"once":   true,
+			"signal": controller.Get("signal"),
+		},
+	)
+
+	tsFn := v.Env.NewThreadsafeFunc("napi-go/js-abort-signal", controller)
+	tsFn.Unref(v.Env)
+
+	go func() {
+		<-ctx.Done()
+		defer tsFn.Release()
+		tsFn.Call(func(env Env, this Value) {
+			this.Call("abort")
+		})
+	}()
+
+	return ctx, func() { cancel(context.Canceled) }
+}
+
+// AbortSignalFor returns an AbortSignal that aborts once ctx is done, with
+// context.Cause(ctx) as its reason. The signal does not keep the process
+// alive while ctx is pending.
+func (e Env) AbortSignalFor(ctx context.Context) Value {
+	controller := e.Global().Get("AbortController").New()
+	if ctx.Err() != nil {
+		controller.Call("abort", context.Cause(ctx))
+		return controller.Get("signal")
+	}
+
+	tsFn := e.NewThreadsafeFunc("napi-go/js-abort-controller", controller)
+	tsFn.Unref(e)
+
+	go func() {
+		<-ctx.Done()
+		defer tsFn.Release()
+		tsFn.Call(func(env Env, this Value) {
+			this.Call("abort", context.Cause(ctx))
+		})
+	}()
+
+	return controller.Get("signal")
+}
+
+func abortReason(signal Value) error {
+	if err := signal.Get("reason").AsError(); err != nil {
+		return err
+	}
+	return context.Canceled
+}
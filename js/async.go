@@ -0,0 +1,85 @@
+package js
+
+import (
+	"github.com/abhisekp/napi-go"
+)
+
+// Async runs fn on the libuv thread pool and returns a promise that is
+// resolved with its result, or rejected with its error, in the async context
+// of the caller. fn runs outside of the JS thread, so it must not use the env
+// or any Value. Panics in fn reject the promise with a napi.PanicError.
+func (e Env) Async(name string, fn func() (any, error)) Value {
+	promise, st := napi.CreatePromise(e.Env)
+	if st != napi.StatusOK {
+		panic(napi.NewError(e.Env, "CreatePromise", st))
+	}
+
+	ctx := e.NewAsyncContext(name)
+	var (
+		work   napi.AsyncWork
+		result any
+	)
+	work, st = napi.CreateAsyncWork(
+		e.Env,
+		ctx.resource.Value().Value, e.ValueOf(name).Value,
+		func(napi.Env) error {
+			var err error
+			result, err = fn()
+			return err
+		},
+		func(env napi.Env, status napi.Status, err error) {
+			defer napi.DeleteAsyncWork(env, work)
+			defer ctx.Destroy()
+
+			if err == nil && status != napi.StatusOK {
+				// the status is not that of a failed call, so there is no
+				// error info to retrieve from env
+				err = napi.NewError(nil, "QueueAsyncWork", status)
+			}
+			ctx.Run(func(env Env) {
+				settleAsync(env, promise.Deferred, result, err)
+			})
+		},
+	)
+	if st != napi.StatusOK {
+		ctx.Destroy()
+		panic(napi.NewError(e.Env, "CreateAsyncWork", st))
+	}
+
+	st = napi.QueueAsyncWork(e.Env, work)
+	if st != napi.StatusOK {
+		err := napi.NewError(e.Env, "QueueAsyncWork", st)
+		napi.DeleteAsyncWork(e.Env, work)
+		ctx.Destroy()
+		panic(err)
+	}
+
+	return Value{
+		Env:   e,
+		Value: promise.Value,
+	}
+}
+
+// settleAsync settles the promise of Async with result, or rejects it with
+// err if it is not nil.
+func settleAsync(env Env, deferred napi.Deferred, result any, err error) {
+	var resolution Value
+	if err == nil {
+		err = env.catch(func() {
+			resolution = env.ValueOf(result)
+		})
+	}
+
+	if err != nil {
+		st := napi.RejectDeferred(env.Env, deferred, env.ValueOf(err).Value)
+		if st != napi.StatusOK {
+			panic(napi.NewError(env.Env, "RejectDeferred", st))
+		}
+		return
+	}
+
+	st := napi.ResolveDeferred(env.Env, deferred, resolution.Value)
+	if st != napi.StatusOK {
+		panic(napi.NewError(env.Env, "ResolveDeferred", st))
+	}
+}
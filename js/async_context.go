@@ -0,0 +1,104 @@
+package js
+
+import (
+	"log/slog"
+	"sync"
+
+	"github.com/abhisekp/napi-go"
+)
+
+// AsyncContext captures the async context of the JS code that is running when
+// it is created, such as the stores of AsyncLocalStorage. JS functions called
+// through it later observe that context instead of an empty one.
+//
+// The js package runs its own calls into JS from outside of JS calls inside
+// an AsyncContext: ThreadsafeFunc, and therefore Emitter and the streams,
+// capture one when they are created, Promise when it is created, and Async
+// when it is called.
+//
+// An AsyncContext must be used and destroyed on the JS thread.
+type AsyncContext struct {
+	env      Env
+	resource *Ref
+	context  napi.AsyncContext
+	once     sync.Once
+}
+
+// NewAsyncContext captures the current async context. name identifies the
+// context in async_hooks.
+func (e Env) NewAsyncContext(name string) *AsyncContext {
+	resource := e.Global().Get("Object").New()
+	resourceName := e.ValueOf(name)
+
+	context, st := napi.AsyncInit(e.Env, resource.Value, resourceName.Value)
+	if st != napi.StatusOK {
+		panic(napi.NewError(e.Env, "AsyncInit", st))
+	}
+
+	return &AsyncContext{
+		env:      e,
+		resource: e.NewRef(resource),
+		context:  context,
+	}
+}
+
+// MakeCallback calls fn with this as the receiver inside c. Like a callback
+// made by Node itself, the microtask queue is drained afterwards if fn was not
+// called from JS.
+func (c *AsyncContext) MakeCallback(fn, this Value, args ...any) Value {
+	argv := c.env.valuesOf(args)
+	result, st := napi.MakeCallback(
+		c.env.Env,
+		c.context,
+		this.Value, fn.Value,
+		len(argv), argv,
+	)
+	if st != napi.StatusOK {
+		panic(napi.NewError(c.env.Env, "MakeCallback", st))
+	}
+	return Value{
+		Env:   c.env,
+		Value: result,
+	}
+}
+
+// Run calls fn inside c, so that JS functions invoked by fn and promises
+// settled by it run in the captured context.
+func (c *AsyncContext) Run(fn func(env Env)) {
+	c.env.Scope(func(env Env) {
+		scope, st := napi.OpenCallbackScope(
+			env.Env,
+			c.resource.Value().Value,
+			c.context,
+		)
+		if st != napi.StatusOK {
+			panic(napi.NewError(env.Env, "OpenCallbackScope", st))
+		}
+
+		defer func() {
+			// panicking here would replace a panic of fn, which is more
+			// interesting, so the failure is only logged
+			st := napi.CloseCallbackScope(env.Env, scope)
+			if st != napi.StatusOK {
+				napi.Logger().Error(
+					"Failed to close callback scope",
+					slog.String("source", "js.AsyncContext.Run"),
+					slog.Any("error", napi.NewError(env.Env, "CloseCallbackScope", st)),
+				)
+			}
+		}()
+
+		fn(env)
+	})
+}
+
+// Destroy releases c. It is a no-op if c has already been destroyed.
+func (c *AsyncContext) Destroy() {
+	c.once.Do(func() {
+		st := napi.AsyncDestroy(c.env.Env, c.context)
+		if st != napi.StatusOK {
+			panic(napi.NewError(c.env.Env, "AsyncDestroy", st))
+		}
+		c.resource.Delete()
+	})
+}
@@ -0,0 +1,99 @@
+package js
+
+import (
+	"runtime"
+	"unsafe"
+
+	"github.com/abhisekp/napi-go"
+)
+
+// ExternalArrayBuffer exposes data to JS as an ArrayBuffer without copying.
+//
+// The backing array of data is pinned and reported to the engine through
+// napi.AdjustExternalMemory until the ArrayBuffer is garbage collected, at
+// which point release (if not nil) is invoked on the JS thread. data must not
+// be modified from Go while JS may observe it.
+//
+// Runtimes that disallow external buffers (such as Electron with the V8
+// sandbox enabled) cause data to be copied into a regular ArrayBuffer
+// instead, in which case release is invoked before ExternalArrayBuffer
+// returns.
+func (e Env) ExternalArrayBuffer(data []byte, release func()) Value {
+	if len(data) == 0 {
+		return e.copyArrayBuffer(data, release)
+	}
+
+	var pinner runtime.Pinner
+	pinner.Pin(&data[0])
+
+	size := int64(len(data))
+	v, st := napi.CreateExternalArrayBuffer(
+		e.Env,
+		unsafe.Pointer(&data[0]),
+		len(data),
+		func(env napi.Env, finalizeData, finalizeHint unsafe.Pointer) {
+			pinner.Unpin()
+			napi.AdjustExternalMemory(env, -size)
+			if release != nil {
+				release()
+			}
+		},
+		nil,
+	)
+
+	switch st {
+	case napi.StatusOK:
+	case napi.StatusNoExternalBuffersAllowed:
+		pinner.Unpin()
+		return e.copyArrayBuffer(data, release)
+	default:
+		pinner.Unpin()
+		panic(napi.NewError(e.Env, "CreateExternalArrayBuffer", st))
+	}
+
+	if _, st := napi.AdjustExternalMemory(e.Env, size); st != napi.StatusOK {
+		panic(napi.NewError(e.Env, "AdjustExternalMemory", st))
+	}
+
+	return Value{
+		Env:   e,
+		Value: v,
+	}
+}
+
+func (e Env) copyArrayBuffer(data []byte, release func()) Value {
+	v, ptr, st := napi.CreateArrayBuffer(e.Env, len(data))
+	if st != napi.StatusOK {
+		panic(napi.NewError(e.Env, "CreateArrayBuffer", st))
+	}
+
+	if len(data) > 0 {
+		copy(unsafe.Slice(ptr, len(data)), data)
+	}
+
+	if release != nil {
+		release()
+	}
+
+	return Value{
+		Env:   e,
+		Value: v,
+	}
+}
+
+// BufferFrom returns a Buffer that shares length bytes of the ArrayBuffer ab,
+// starting at offset, like Buffer.from(ab, offset, length) in JS.
+func (e Env) BufferFrom(ab Value, offset, length int) Value {
+	if !e.Supports(FeatureBufferFromArrayBuffer) {
+		return e.Global().Get("Buffer").Call("from", ab, offset, length)
+	}
+
+	v, st := napi.CreateBufferFromArrayBuffer(e.Env, ab.Value, offset, length)
+	if st != napi.StatusOK {
+		panic(napi.NewError(e.Env, "CreateBufferFromArrayBuffer", st))
+	}
+	return Value{
+		Env:   e,
+		Value: v,
+	}
+}
@@ -8,18 +8,13 @@ type Callback = func(env Env, this Value, args []Value) any
 
 func AsCallback(fn Callback) napi.Callback {
 	return func(env napi.Env, info napi.CallbackInfo) napi.Value {
-		cbInfo, st := napi.GetCbInfo(env, info)
-		if st != napi.StatusOK {
-			panic(napi.StatusError(st))
-		}
-
 		jsEnv := AsEnv(env)
 		this := Value{
 			Env:   jsEnv,
-			Value: cbInfo.This,
+			Value: info.This,
 		}
-		args := make([]Value, len(cbInfo.Args))
-		for i, cbArg := range cbInfo.Args {
+		args := make([]Value, len(info.Args))
+		for i, cbArg := range info.Args {
 			args[i] = Value{
 				Env:   jsEnv,
 				Value: cbArg,
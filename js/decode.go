@@ -0,0 +1,393 @@
+package js
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"unsafe"
+
+	"github.com/abhisekp/napi-go"
+)
+
+// DecodeError describes a JS value that cannot be stored in a Go value of a
+// given type.
+type DecodeError struct {
+	ValueType napi.ValueType
+	Type      reflect.Type
+}
+
+var _ error = DecodeError{}
+
+var ErrDecodeTarget = errors.New(
+	"Decode: target must be a non-nil pointer",
+)
+
+var (
+	valueType  = reflect.TypeOf(Value{})
+	symbolType = reflect.TypeOf(Symbol{})
+)
+
+// Decode stores v in the Go value pointed to by target, the inverse of
+// ValueOf.
+//
+// Arrays, Sets and other iterables decode into slices and arrays. Maps
+// decode into Go maps, as do plain objects, whose property names are
+// converted to the map's key type. A Set decodes into a map whose values are
+// set to true for bool maps and left zero otherwise, such as for
+// map[T]struct{}. Objects decode into structs using the same field names as
+// ValueOf. Buffers and typed arrays decode into byte slices. Numbers and
+// BigInts only decode into integer types if they are integers that the type
+// can hold.
+//
+// Into an empty interface, objects decode as map[string]any, arrays and Sets
+// as []any, Maps as map[any]any, numbers as float64, symbols as Symbol, and
+// functions and other values that have no Go equivalent as Value.
+func (v Value) Decode(target any) error {
+	rv := reflect.ValueOf(target)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return ErrDecodeTarget
+	}
+
+	return v.Env.catch(func() {
+		v.decode(rv.Elem())
+	})
+}
+
+func (v Value) decode(rv reflect.Value) {
+	if rv.Type() == valueType {
+		rv.Set(reflect.ValueOf(v))
+		return
+	}
+
+	vt := v.Type()
+	if rv.Type() == symbolType && vt == napi.ValueTypeSymbol {
+		rv.Set(reflect.ValueOf(Symbol{v}))
+		return
+	}
+	switch rv.Kind() {
+	case reflect.Pointer:
+		if vt == napi.ValueTypeNull || vt == napi.ValueTypeUndefined {
+			rv.SetZero()
+			return
+		}
+		if rv.IsNil() {
+			rv.Set(reflect.New(rv.Type().Elem()))
+		}
+		v.decode(rv.Elem())
+		return
+
+	case reflect.Interface:
+		if rv.NumMethod() != 0 {
+			break
+		}
+		if x := v.decodeAny(vt); x != nil {
+			rv.Set(reflect.ValueOf(x))
+		} else {
+			rv.SetZero()
+		}
+		return
+
+	case reflect.Bool:
+		if vt == napi.ValueTypeBoolean {
+			rv.SetBool(v.Bool())
+			return
+		}
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		switch vt {
+		case napi.ValueTypeNumber:
+			if f := v.Float(); isInteger(f) && f >= -1<<63 && f < 1<<63 && !rv.OverflowInt(int64(f)) {
+				rv.SetInt(int64(f))
+				return
+			}
+		case napi.ValueTypeBigint:
+			x, lossless, st := napi.GetValueBigIntInt64(v.Env.Env, v.Value)
+			if st != napi.StatusOK {
+				panic(napi.NewError(v.Env.Env, "GetValueBigIntInt64", st))
+			}
+			if lossless && !rv.OverflowInt(x) {
+				rv.SetInt(x)
+				return
+			}
+		}
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		switch vt {
+		case napi.ValueTypeNumber:
+			if f := v.Float(); isInteger(f) && f >= 0 && f < 1<<64 && !rv.OverflowUint(uint64(f)) {
+				rv.SetUint(uint64(f))
+				return
+			}
+		case napi.ValueTypeBigint:
+			x, lossless, st := napi.GetValueBigIntUint64(v.Env.Env, v.Value)
+			if st != napi.StatusOK {
+				panic(napi.NewError(v.Env.Env, "GetValueBigIntUint64", st))
+			}
+			if lossless && !rv.OverflowUint(x) {
+				rv.SetUint(x)
+				return
+			}
+		}
+
+	case reflect.Float32, reflect.Float64:
+		if vt == napi.ValueTypeNumber {
+			rv.SetFloat(v.Float())
+			return
+		}
+
+	case reflect.String:
+		if vt == napi.ValueTypeString {
+			rv.SetString(v.String())
+			return
+		}
+
+	case reflect.Slice:
+		if vt == napi.ValueTypeNull || vt == napi.ValueTypeUndefined {
+			rv.SetZero()
+			return
+		}
+		if rv.Type().Elem().Kind() == reflect.Uint8 && v.isTypedArray() {
+			rv.SetBytes(v.bytes())
+			return
+		}
+		if vt == napi.ValueTypeObject {
+			items := v.arrayOf()
+			l := items.Length()
+			rv.Set(reflect.MakeSlice(rv.Type(), l, l))
+			for i := 0; i < l; i++ {
+				items.Index(i).decode(rv.Index(i))
+			}
+			return
+		}
+
+	case reflect.Array:
+		if vt == napi.ValueTypeObject {
+			items := v.arrayOf()
+			l := items.Length()
+			for i := 0; i < rv.Len(); i++ {
+				if i < l {
+					items.Index(i).decode(rv.Index(i))
+				} else {
+					rv.Index(i).SetZero()
+				}
+			}
+			return
+		}
+
+	case reflect.Map:
+		if vt == napi.ValueTypeNull || vt == napi.ValueTypeUndefined {
+			rv.SetZero()
+			return
+		}
+		if vt == napi.ValueTypeObject {
+			v.decodeMap(rv)
+			return
+		}
+
+	case reflect.Struct:
+		if vt == napi.ValueTypeObject || vt == napi.ValueTypeFunction {
+			for _, f := range structFields(rv.Type()) {
+				fv := v.getKey(v.Env.Key(f.Name))
+				if fv.IsUndefined() {
+					continue
+				}
+				fv.decode(fieldByIndexAlloc(rv, f.Index))
+			}
+			return
+		}
+	}
+
+	panic(DecodeError{
+		ValueType: vt,
+		Type:      rv.Type(),
+	})
+}
+
+func (v Value) decodeAny(vt napi.ValueType) any {
+	switch vt {
+	case napi.ValueTypeUndefined, napi.ValueTypeNull:
+		return nil
+	case napi.ValueTypeBoolean:
+		return v.Bool()
+	case napi.ValueTypeNumber:
+		return v.Float()
+	case napi.ValueTypeString:
+		return v.String()
+	case napi.ValueTypeSymbol:
+		return Symbol{v}
+	case napi.ValueTypeObject:
+		var target any
+		switch {
+		case v.isArray() || v.isSet():
+			target = &[]any{}
+		case v.isMap():
+			target = &map[any]any{}
+		case v.isTypedArray():
+			return v.bytes()
+		default:
+			target = &map[string]any{}
+		}
+
+		v.decode(reflect.ValueOf(target).Elem())
+		return reflect.ValueOf(target).Elem().Interface()
+	}
+
+	return v
+}
+
+func (v Value) decodeMap(rv reflect.Value) {
+	t := rv.Type()
+	if rv.IsNil() {
+		rv.Set(reflect.MakeMap(t))
+	}
+
+	switch {
+	case v.isMap():
+		entries := v.arrayOf()
+		for i, l := 0, entries.Length(); i < l; i++ {
+			entry := entries.Index(i)
+			k, x := reflect.New(t.Key()).Elem(), reflect.New(t.Elem()).Elem()
+			entry.Index(0).decode(k)
+			entry.Index(1).decode(x)
+			rv.SetMapIndex(k, x)
+		}
+	case v.isSet():
+		items := v.arrayOf()
+		for i, l := 0, items.Length(); i < l; i++ {
+			k, x := reflect.New(t.Key()).Elem(), reflect.New(t.Elem()).Elem()
+			items.Index(i).decode(k)
+			if x.Kind() == reflect.Bool {
+				x.SetBool(true)
+			}
+			rv.SetMapIndex(k, x)
+		}
+	default:
+		names, st := napi.GetPropertyNames(v.Env.Env, v.Value)
+		if st != napi.StatusOK {
+			panic(napi.NewError(v.Env.Env, "GetPropertyNames", st))
+		}
+
+		keys := Value{Env: v.Env, Value: names}
+		for i, l := 0, keys.Length(); i < l; i++ {
+			name := keys.Index(i).String()
+			k := reflect.New(t.Key()).Elem()
+			if err := setMapKey(k, name); err != nil {
+				panic(err)
+			}
+
+			x := reflect.New(t.Elem()).Elem()
+			v.Get(name).decode(x)
+			rv.SetMapIndex(k, x)
+		}
+	}
+}
+
+// arrayOf returns v if it is an array, or an array of its entries as created
+// by Array.from otherwise.
+func (v Value) arrayOf() Value {
+	if v.isArray() {
+		return v
+	}
+	return v.Env.Global().Get("Array").Call("from", v)
+}
+
+func (v Value) isArray() bool {
+	result, st := napi.IsArray(v.Env.Env, v.Value)
+	if st != napi.StatusOK {
+		panic(napi.NewError(v.Env.Env, "IsArray", st))
+	}
+	return result
+}
+
+func (v Value) isTypedArray() bool {
+	result, st := napi.IsTypedArray(v.Env.Env, v.Value)
+	if st != napi.StatusOK {
+		panic(napi.NewError(v.Env.Env, "IsTypedArray", st))
+	}
+	return result
+}
+
+func (v Value) isMap() bool {
+	return v.InstanceOf(v.Env.Global().Get("Map"))
+}
+
+func (v Value) isSet() bool {
+	return v.InstanceOf(v.Env.Global().Get("Set"))
+}
+
+// bytes returns a copy of the memory viewed by the typed array v.
+func (v Value) bytes() []byte {
+	_, _, data, _, _, st := napi.GetTypedArrayInfo(v.Env.Env, v.Value)
+	if st != napi.StatusOK {
+		panic(napi.NewError(v.Env.Env, "GetTypedArrayInfo", st))
+	}
+
+	// the length reported above counts elements rather than bytes
+	size := v.Get("byteLength").Int()
+	if data == nil || size == 0 {
+		return []byte{}
+	}
+	return append([]byte(nil), unsafe.Slice(data, size)...)
+}
+
+func setMapKey(k reflect.Value, name string) error {
+	switch k.Kind() {
+	case reflect.String:
+		k.SetString(name)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(name, 10, k.Type().Bits())
+		if err != nil {
+			return err
+		}
+		k.SetInt(n)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(name, 10, k.Type().Bits())
+		if err != nil {
+			return err
+		}
+		k.SetUint(n)
+		return nil
+	case reflect.Interface:
+		if k.NumMethod() == 0 {
+			k.Set(reflect.ValueOf(name))
+			return nil
+		}
+	}
+
+	return DecodeError{
+		ValueType: napi.ValueTypeString,
+		Type:      k.Type(),
+	}
+}
+
+// fieldByIndexAlloc is like reflect.Value.FieldByIndex, but allocates nil
+// embedded pointers on the way.
+func fieldByIndexAlloc(rv reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && rv.Kind() == reflect.Pointer {
+			if rv.IsNil() {
+				rv.Set(reflect.New(rv.Type().Elem()))
+			}
+			rv = rv.Elem()
+		}
+		rv = rv.Field(x)
+	}
+	return rv
+}
+
+// isInteger reports whether f is a finite number without a fractional part.
+func isInteger(f float64) bool {
+	return f == math.Trunc(f) && !math.IsInf(f, 0)
+}
+
+func (err DecodeError) Error() string {
+	return fmt.Sprintf(
+		"Value of type %s cannot be decoded into Go type %s",
+		err.ValueType,
+		err.Type,
+	)
+}
@@ -0,0 +1,260 @@
+package js
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/abhisekp/napi-go"
+)
+
+// DynamicObject serves the properties of a JS object created by NewDynamic.
+// Its methods are called on the JS thread whenever JS accesses a property.
+type DynamicObject interface {
+	// Get returns the property key, or false if there is no such property.
+	Get(key string) (value any, ok bool)
+	// Set sets the property key and reports whether it was set. In strict
+	// mode code, JS throws a TypeError when it was not.
+	Set(key string, value Value) bool
+	// Has reports whether the property key exists.
+	Has(key string) bool
+	// Keys returns the names of the properties, in enumeration order.
+	Keys() []string
+	// Delete deletes the property key and reports whether it was deleted.
+	Delete(key string) bool
+}
+
+// dynamicTarget is attached to the target of the proxies of NewDynamic.
+type dynamicTarget struct {
+	obj DynamicObject
+	// detached is set once the proxy has been made non-extensible. The
+	// properties of obj have then been copied to the target, which serves
+	// them from then on.
+	detached bool
+}
+
+var (
+	dynamicTargetType = reflect.TypeOf(dynamicTarget{})
+
+	dynamicHandlers sync.Map // map[napi.Env]*Ref
+)
+
+// NewDynamic returns a JS Proxy whose string-keyed properties are served by
+// obj, so that properties are only computed when JS accesses them. The
+// properties appear as own, enumerable and writable data properties, so
+// Object.keys, spreading and JSON.stringify see the keys reported by
+// obj.Keys. Properties that obj does not have, such as toString, and
+// symbol-keyed properties are looked up on an ordinary object instead.
+// Object.defineProperty sets string-keyed properties through obj.Set, and
+// fails for accessors and for attributes other than the defaults. obj is kept
+// alive until the proxy is garbage collected.
+//
+// Making the proxy non-extensible, as Object.freeze does, copies the
+// properties of obj to the ordinary object and detaches the proxy from obj.
+func (e Env) NewDynamic(obj DynamicObject) Value {
+	target, st := napi.CreateObject(e.Env)
+	if st != napi.StatusOK {
+		panic(napi.NewError(e.Env, "CreateObject", st))
+	}
+
+	t := Value{Env: e, Value: target}
+	t.wrap(&dynamicTarget{obj: obj}, typeTagFor(dynamicTargetType))
+	return e.Global().Get("Proxy").New(t, e.dynamicHandler())
+}
+
+// dynamicHandler returns the proxy handler shared by the dynamic objects of
+// e. The traps find the DynamicObject through their target argument.
+func (e Env) dynamicHandler() Value {
+	if ref, ok := dynamicHandlers.Load(e.Env); ok {
+		return ref.(*Ref).Value()
+	}
+
+	handler := e.ValueOf(map[string]any{
+		"get":                      Callback(dynamicGet),
+		"set":                      Callback(dynamicSet),
+		"has":                      Callback(dynamicHas),
+		"deleteProperty":           Callback(dynamicDeleteProperty),
+		"ownKeys":                  Callback(dynamicOwnKeys),
+		"getOwnPropertyDescriptor": Callback(dynamicGetOwnPropertyDescriptor),
+		"defineProperty":           Callback(dynamicDefineProperty),
+		"preventExtensions":        Callback(dynamicPreventExtensions),
+	})
+	dynamicHandlers.Store(e.Env, e.NewRef(handler))
+
+	_, st := napi.AddEnvCleanupHook(e.Env, func() {
+		dynamicHandlers.Delete(e.Env)
+	})
+	if st != napi.StatusOK {
+		panic(napi.NewError(e.Env, "AddEnvCleanupHook", st))
+	}
+	return handler
+}
+
+// dynamicTargetOf returns the dynamicTarget attached to the target of a
+// proxy trap.
+func dynamicTargetOf(target Value) *dynamicTarget {
+	x, err := target.unwrap(dynamicTargetType)
+	if err != nil {
+		panic(err)
+	}
+	return x.(*dynamicTarget)
+}
+
+// dynamicObjectOf returns the DynamicObject attached to the target of a
+// proxy trap, or nil if the proxy has been detached from it.
+func dynamicObjectOf(target Value) DynamicObject {
+	if t := dynamicTargetOf(target); !t.detached {
+		return t.obj
+	}
+	return nil
+}
+
+// reflect calls Reflect[trap] with args, which implements the default
+// behavior of a trap on the target.
+func (e Env) reflect(trap string, args []Value) Value {
+	xs := make([]any, len(args))
+	for i, arg := range args {
+		xs[i] = arg
+	}
+	return e.Global().Get("Reflect").Call(trap, xs...)
+}
+
+// hasOwn reports whether v has the own property key.
+func (v Value) hasOwn(key string) bool {
+	result, st := napi.HasOwnProperty(v.Env.Env, v.Value, v.Env.Key(key).Value)
+	if st != napi.StatusOK {
+		panic(napi.NewError(v.Env.Env, "HasOwnProperty", st))
+	}
+	return result
+}
+
+// The traps below receive the arguments documented for Proxy handlers,
+// starting with the target and the property key. Symbol keys, and all keys
+// once the proxy is detached, are left to the target.
+
+func dynamicGet(env Env, this Value, args []Value) any {
+	target, key := args[0], args[1]
+	if obj := dynamicObjectOf(target); obj != nil && !key.IsSymbol() {
+		if v, ok := obj.Get(key.String()); ok {
+			return v
+		}
+	}
+	return target.getKey(key)
+}
+
+func dynamicSet(env Env, this Value, args []Value) any {
+	target, key, value := args[0], args[1], args[2]
+	obj := dynamicObjectOf(target)
+	if obj == nil || key.IsSymbol() {
+		return env.reflect("set", args)
+	}
+	return obj.Set(key.String(), value)
+}
+
+func dynamicHas(env Env, this Value, args []Value) any {
+	target, key := args[0], args[1]
+	if obj := dynamicObjectOf(target); obj != nil && !key.IsSymbol() && obj.Has(key.String()) {
+		return true
+	}
+
+	result, st := napi.HasProperty(env.Env, target.Value, key.Value)
+	if st != napi.StatusOK {
+		panic(napi.NewError(env.Env, "HasProperty", st))
+	}
+	return result
+}
+
+func dynamicDeleteProperty(env Env, this Value, args []Value) any {
+	target, key := args[0], args[1]
+	if obj := dynamicObjectOf(target); obj != nil && !key.IsSymbol() {
+		return obj.Delete(key.String())
+	}
+
+	result, st := napi.DeleteProperty(env.Env, target.Value, key.Value)
+	if st != napi.StatusOK {
+		panic(napi.NewError(env.Env, "DeleteProperty", st))
+	}
+	return result
+}
+
+func dynamicOwnKeys(env Env, this Value, args []Value) any {
+	target := args[0]
+	targetKeys := env.reflect("ownKeys", args[:1])
+	obj := dynamicObjectOf(target)
+	if obj == nil {
+		return targetKeys
+	}
+
+	// while attached, the target only holds symbol-keyed properties, so the
+	// keys cannot overlap
+	keys := obj.Keys()
+	result := make([]any, len(keys), len(keys)+targetKeys.Length())
+	for i, k := range keys {
+		result[i] = k
+	}
+	for i, n := 0, targetKeys.Length(); i < n; i++ {
+		result = append(result, targetKeys.Index(i))
+	}
+	return result
+}
+
+func dynamicGetOwnPropertyDescriptor(env Env, this Value, args []Value) any {
+	target, key := args[0], args[1]
+	obj := dynamicObjectOf(target)
+	if obj == nil || key.IsSymbol() {
+		return env.reflect("getOwnPropertyDescriptor", args)
+	}
+
+	name := key.String()
+	if !obj.Has(name) {
+		return env.reflect("getOwnPropertyDescriptor", args)
+	}
+
+	value, _ := obj.Get(name)
+	return map[string]any{
+		"value":        value,
+		"writable":     true,
+		"enumerable":   true,
+		"configurable": true,
+	}
+}
+
+func dynamicDefineProperty(env Env, this Value, args []Value) any {
+	target, key, desc := args[0], args[1], args[2]
+	obj := dynamicObjectOf(target)
+	if obj == nil || key.IsSymbol() {
+		return env.reflect("defineProperty", args)
+	}
+
+	// obj can only hold properties with the attributes reported by
+	// dynamicGetOwnPropertyDescriptor
+	for _, attr := range []string{"get", "set"} {
+		if desc.hasOwn(attr) {
+			return false
+		}
+	}
+	for _, attr := range []string{"writable", "enumerable", "configurable"} {
+		if desc.hasOwn(attr) && !desc.Get(attr).Truthy() {
+			return false
+		}
+	}
+
+	name := key.String()
+	if !desc.hasOwn("value") && obj.Has(name) {
+		return true
+	}
+	return obj.Set(name, desc.Get("value"))
+}
+
+func dynamicPreventExtensions(env Env, this Value, args []Value) any {
+	target := args[0]
+	t := dynamicTargetOf(target)
+	if !t.detached {
+		// the target must hold every property reported from now on
+		for _, k := range t.obj.Keys() {
+			v, _ := t.obj.Get(k)
+			target.Set(k, v)
+		}
+		t.detached = true
+	}
+	return env.reflect("preventExtensions", args)
+}
@@ -0,0 +1,136 @@
+package js
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/abhisekp/napi-go"
+)
+
+// Emitter drives a Node.js EventEmitter from Go. Its methods may be called
+// from any goroutine; the work is carried out on the JS thread.
+//
+// The emitter keeps the process alive only while it has listeners, so an
+// idle emitter does not prevent Node.js from exiting.
+type Emitter struct {
+	// Value is the underlying EventEmitter. Like other values, it is only
+	// valid within the callback that created the Emitter.
+	Value Value
+
+	tsFn   *ThreadsafeFunc
+	lock   sync.RWMutex
+	closed bool
+}
+
+var ErrEmitterClosed = errors.New(
+	"Emitter: Cannot use a closed emitter",
+)
+
+// NewEmitter creates a new EventEmitter.
+func (e Env) NewEmitter() *Emitter {
+	v := e.Require("events").Get("EventEmitter").New()
+	return v.AsEmitter()
+}
+
+// AsEmitter wraps v, which must be an EventEmitter, in an Emitter.
+func (v Value) AsEmitter() *Emitter {
+	result := &Emitter{
+		Value: v,
+		tsFn:  v.Env.NewThreadsafeFunc("napi-go/js-emitter", v),
+	}
+
+	// newListener is emitted before the listener is added, removeListener
+	// after it has been removed
+	v.Call("on", "newListener", func(env Env, this Value, args []Value) any {
+		pending := 1
+		if isEmitterHookEvent(args[0]) {
+			pending = 0
+		}
+		result.updateRef(env, this, pending)
+		return nil
+	})
+	v.Call("on", "removeListener", func(env Env, this Value, args []Value) any {
+		result.updateRef(env, this, 0)
+		return nil
+	})
+	result.updateRef(v.Env, v, 0)
+
+	return result
+}
+
+// Emit calls the listeners registered for the event name with args, which
+// are converted with ValueOf on the JS thread.
+func (em *Emitter) Emit(name string, args ...any) error {
+	return em.do(func(env Env, this Value) {
+		this.Call("emit", append([]any{name}, args...)...)
+	})
+}
+
+// On registers cb as a listener for the event name.
+func (em *Emitter) On(name string, cb Callback) error {
+	return em.do(func(env Env, this Value) {
+		this.Call("on", name, cb)
+	})
+}
+
+// Close releases the emitter. Events already emitted are still delivered.
+func (em *Emitter) Close() {
+	em.lock.Lock()
+	defer em.lock.Unlock()
+
+	if em.closed {
+		return
+	}
+
+	em.closed = true
+	em.tsFn.Release()
+}
+
+func (em *Emitter) do(fn func(env Env, this Value)) error {
+	em.lock.RLock()
+	defer em.lock.RUnlock()
+
+	if em.closed {
+		return ErrEmitterClosed
+	}
+	return em.tsFn.Call(fn)
+}
+
+// updateRef refs the emitter while it has listeners, not counting the ones
+// installed by AsEmitter.
+func (em *Emitter) updateRef(env Env, emitter Value, pending int) {
+	em.lock.RLock()
+	defer em.lock.RUnlock()
+
+	if em.closed {
+		return
+	}
+
+	count := pending
+	names := emitter.Call("eventNames")
+	for i, l := 0, names.Length(); i < l; i++ {
+		name := names.Index(i)
+		if isEmitterHookEvent(name) {
+			continue
+		}
+		count += emitter.Call("listenerCount", name).Int()
+	}
+
+	if count > 0 {
+		em.tsFn.Ref(env)
+	} else {
+		em.tsFn.Unref(env)
+	}
+}
+
+func isEmitterHookEvent(name Value) bool {
+	if name.Type() != napi.ValueTypeString {
+		return false
+	}
+
+	switch name.String() {
+	case "newListener", "removeListener":
+		return true
+	}
+	return false
+}
@@ -2,6 +2,7 @@ package js
 
 import (
 	"fmt"
+	"reflect"
 	"unsafe"
 
 	"github.com/abhisekp/napi-go"
@@ -26,7 +27,7 @@ func AsEnv(env napi.Env) Env {
 func (e Env) Global() Value {
 	v, st := napi.GetGlobal(e.Env)
 	if st != napi.StatusOK {
-		panic(napi.StatusError(st))
+		panic(napi.NewError(e.Env, "GetGlobal", st))
 	}
 	return Value{
 		Env:   e,
@@ -37,7 +38,7 @@ func (e Env) Global() Value {
 func (e Env) Null() Value {
 	v, st := napi.GetNull(e.Env)
 	if st != napi.StatusOK {
-		panic(napi.StatusError(st))
+		panic(napi.NewError(e.Env, "GetNull", st))
 	}
 	return Value{
 		Env:   e,
@@ -48,7 +49,7 @@ func (e Env) Null() Value {
 func (e Env) Undefined() Value {
 	v, st := napi.GetUndefined(e.Env)
 	if st != napi.StatusOK {
-		panic(napi.StatusError(st))
+		panic(napi.NewError(e.Env, "GetUndefined", st))
 	}
 	return Value{
 		Env:   e,
@@ -57,6 +58,13 @@ func (e Env) Undefined() Value {
 }
 
 func (e Env) ValueOf(x any) Value {
+	return e.valueOf(x, true)
+}
+
+// valueOf is ValueOf, but only considers converting slices and maps through
+// JSON if viaJSON is set. The elements of a slice or map that was considered
+// are converted with viaJSON unset, because its cost already covered them.
+func (e Env) valueOf(x any, viaJSON bool) Value {
 	var (
 		v  napi.Value
 		st napi.Status
@@ -72,17 +80,20 @@ func (e Env) ValueOf(x any) Value {
 			break
 		}
 
+		result := Value{Env: e, Value: v}
 		for i, xti := range xt {
-			// TODO: Use Value.SetIndex helper
-			st = napi.SetElement(e.Env, v, i, xti.Value)
-			if st != napi.StatusOK {
-				break
-			}
+			result.SetIndex(i, xti)
 		}
 	case Func:
 		return xt.Value
+	case Symbol:
+		return xt.Value
 	case Callback:
 		return e.FuncOf(xt).Value
+	case Producer:
+		return e.AsyncIteratorOf(xt)
+	case *Emitter:
+		return xt.Value
 	case *Promise:
 		v, st = xt.Promise.Value, napi.StatusOK
 	case napi.Value:
@@ -121,42 +132,56 @@ func (e Env) ValueOf(x any) Value {
 	case error:
 		msg := e.ValueOf(xt.Error())
 		v, st = napi.CreateError(e.Env, nil, msg.Value)
+	case []byte:
+		v, _, st = napi.CreateBufferCopy(e.Env, xt)
 	case []any:
+		if viaJSON {
+			if result, ok := e.valueOfJSON(xt); ok {
+				return result
+			}
+		}
+
 		l := len(xt)
 		v, st = napi.CreateArrayWithLength(e.Env, l)
 		if st != napi.StatusOK {
 			break
 		}
 
-		for i, xti := range xt {
-			// TODO: Use Value.SetIndex helper
-			vti := e.ValueOf(xti)
-			st = napi.SetElement(e.Env, v, i, vti.Value)
-			if st != napi.StatusOK {
-				break
-			}
-		}
+		result := Value{Env: e, Value: v}
+		e.batched(l, func(i int) {
+			result.SetIndex(i, e.valueOf(xt[i], false))
+		})
 	case map[string]any:
-		v, st = napi.CreateObject(e.Env)
-		if st != napi.StatusOK {
-			break
+		if viaJSON {
+			if result, ok := e.valueOfJSON(xt); ok {
+				return result
+			}
 		}
 
-		for xtk, xtv := range xt {
-			// TODO: Use Value.Set helper
-			vtk, vtv := e.ValueOf(xtk), e.ValueOf(xtv)
-			st = napi.SetProperty(e.Env, v, vtk.Value, vtv.Value)
-			if st != napi.StatusOK {
-				break
-			}
+		return e.objectOfMap(reflect.ValueOf(xt), false)
+
+	case mapMarshaler:
+		rv := reflect.ValueOf(xt.m)
+		if rv.Kind() != reflect.Map {
+			panic(InvalidValueTypeError{xt.m})
+		}
+		return e.mapOf(rv, viaJSON)
+	case setMarshaler:
+		return e.setOf(reflect.ValueOf(xt.s), viaJSON)
+	case methodsMarshaler:
+		return e.objectOfMethods(xt.x, nil)
+	case Bindable:
+		if rv := reflect.ValueOf(xt); rv.Kind() == reflect.Pointer && rv.IsNil() {
+			return e.Null()
 		}
+		return e.objectOfMethods(xt, xt.JSMethods())
 
 	default:
-		panic(InvalidValueTypeError{x})
+		return e.valueOfReflect(x, viaJSON)
 	}
 
 	if st != napi.StatusOK {
-		panic(napi.StatusError(st))
+		panic(napi.NewError(e.Env, fmt.Sprintf("ValueOf(%T)", x), st))
 	}
 
 	return Value{
@@ -165,8 +190,17 @@ func (e Env) ValueOf(x any) Value {
 	}
 }
 
+func (e Env) valuesOf(xs []any) []napi.Value {
+	result := make([]napi.Value, len(xs))
+	for i, x := range xs {
+		result[i] = e.ValueOf(x).Value
+	}
+	return result
+}
+
+// FuncOf creates a JS function that calls fn. Like any Value, the result is
+// only valid during the current callback; use NewRef to keep it around.
 func (e Env) FuncOf(fn Callback) Func {
-	// TODO: Add CreateReference to FuncOf to keep value alive
 	v, st := napi.CreateFunction(
 		e.Env,
 		"",
@@ -174,7 +208,7 @@ func (e Env) FuncOf(fn Callback) Func {
 	)
 
 	if st != napi.StatusOK {
-		panic(napi.StatusError(st))
+		panic(napi.NewError(e.Env, "CreateFunction", st))
 	}
 
 	return Func{
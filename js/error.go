@@ -0,0 +1,76 @@
+package js
+
+import (
+	"fmt"
+
+	"github.com/abhisekp/napi-go"
+)
+
+// Error is a Go representation of a JS error value.
+type Error struct {
+	Name    string
+	Message string
+}
+
+var _ error = Error{}
+
+func (err Error) Error() string {
+	if err.Name == "" {
+		return err.Message
+	}
+	return fmt.Sprintf("%s: %s", err.Name, err.Message)
+}
+
+// AsError converts v to a Go error. It returns nil for null and undefined.
+// Non-error values are converted to their string representation.
+func (v Value) AsError() error {
+	switch v.Type() {
+	case napi.ValueTypeUndefined, napi.ValueTypeNull:
+		return nil
+	}
+
+	isError, st := napi.IsError(v.Env.Env, v.Value)
+	if st != napi.StatusOK {
+		panic(napi.NewError(v.Env.Env, "IsError", st))
+	}
+
+	// errors implemented in JS, such as DOMException, are not native errors
+	if !isError && v.Type() == napi.ValueTypeObject {
+		isError = v.InstanceOf(v.Env.Global().Get("Error"))
+	}
+
+	if !isError {
+		return Error{Message: v.String()}
+	}
+
+	return Error{
+		Name:    v.Get("name").String(),
+		Message: v.Get("message").String(),
+	}
+}
+
+// catch runs fn and converts a panic or a pending JS exception into an
+// error, clearing the exception so it does not propagate to the caller.
+func (e Env) catch(fn func()) (err error) {
+	defer func() {
+		r := recover()
+
+		pending, st := napi.IsExceptionPending(e.Env)
+		if st == napi.StatusOK && pending {
+			exception, _ := napi.GetAndClearLastException(e.Env)
+			err = Value{Env: e, Value: exception}.AsError()
+			return
+		}
+
+		if r != nil {
+			if rerr, ok := r.(error); ok {
+				err = rerr
+			} else {
+				err = fmt.Errorf("%v", r)
+			}
+		}
+	}()
+
+	fn()
+	return nil
+}
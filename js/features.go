@@ -0,0 +1,93 @@
+package js
+
+import (
+	"sync"
+
+	"github.com/abhisekp/napi-go"
+)
+
+// Feature is an optional Node-API capability that only some Node releases
+// provide.
+type Feature int
+
+const (
+	// FeatureSymbolFor is node_api_symbol_for, which creates symbols in the
+	// global symbol registry.
+	FeatureSymbolFor Feature = iota
+	// FeaturePropertyKeys is node_api_create_property_key_*, used by Key.
+	FeaturePropertyKeys
+	// FeatureExternalStrings is node_api_create_external_string_*, used by
+	// ExternalString.
+	FeatureExternalStrings
+	// FeatureBufferFromArrayBuffer is node_api_create_buffer_from_arraybuffer,
+	// used by BufferFrom.
+	FeatureBufferFromArrayBuffer
+	// FeaturePostFinalizer is node_api_post_finalizer.
+	FeaturePostFinalizer
+)
+
+// features lists the Node-API version that made each feature stable, or 0
+// if it is still experimental, and the functions it consists of.
+var features = map[Feature]struct {
+	version uint32
+	symbols []string
+}{
+	FeatureSymbolFor: {9, []string{
+		"node_api_symbol_for",
+	}},
+	FeaturePropertyKeys: {10, []string{
+		"node_api_create_property_key_latin1",
+		"node_api_create_property_key_utf8",
+		"node_api_create_property_key_utf16",
+	}},
+	FeatureExternalStrings: {10, []string{
+		"node_api_create_external_string_latin1",
+		"node_api_create_external_string_utf16",
+	}},
+	FeatureBufferFromArrayBuffer: {0, []string{
+		"node_api_create_buffer_from_arraybuffer",
+	}},
+	FeaturePostFinalizer: {0, []string{
+		"node_api_post_finalizer",
+	}},
+}
+
+var supportedFeatures sync.Map // map[Feature]bool
+
+// Supports reports whether the running Node release provides f. Features are
+// available once Node supports the Node-API version that made them stable,
+// and experimental features whenever Node exports their functions.
+func (e Env) Supports(f Feature) bool {
+	if cached, ok := supportedFeatures.Load(f); ok {
+		return cached.(bool)
+	}
+
+	feature, ok := features[f]
+	if !ok {
+		return false
+	}
+
+	supported := true
+	if feature.version > 0 {
+		version, st := napi.GetVersion(e.Env)
+		if st != napi.StatusOK {
+			panic(napi.NewError(e.Env, "GetVersion", st))
+		}
+		supported = version >= feature.version
+	}
+
+	// Node releases older than the version above may still export the
+	// functions as experimental.
+	if !supported || feature.version == 0 {
+		supported = true
+		for _, name := range feature.symbols {
+			if !napi.SymbolAvailable(name) {
+				supported = false
+				break
+			}
+		}
+	}
+
+	supportedFeatures.Store(f, supported)
+	return supported
+}
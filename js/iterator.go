@@ -0,0 +1,179 @@
+package js
+
+import (
+	"context"
+	"reflect"
+	"sync"
+
+	"github.com/abhisekp/napi-go"
+)
+
+// Producer generates the values of an async iterator by calling yield for
+// each of them. It runs on its own goroutine and should return when yield
+// returns false, which happens once ctx is cancelled by the iterator's
+// return() method. A non-nil error, or a PanicError if the producer panics,
+// rejects the pending next() call.
+type Producer func(ctx context.Context, yield func(value any) bool) error
+
+type asyncIterator struct {
+	recv   func() (value any, ok bool, err error)
+	cancel context.CancelFunc
+
+	lock sync.Mutex
+	tail chan struct{}
+}
+
+// AsyncIteratorOf returns a JS object implementing Symbol.asyncIterator that
+// yields the values of x, which must be a Producer or a receivable channel.
+// Each value is converted with ValueOf on the JS thread.
+func (e Env) AsyncIteratorOf(x any) Value {
+	var it *asyncIterator
+	switch xt := x.(type) {
+	case Producer:
+		it = producerIterator(xt)
+	default:
+		rv := reflect.ValueOf(x)
+		if rv.Kind() != reflect.Chan || rv.Type().ChanDir()&reflect.RecvDir == 0 {
+			panic(InvalidValueTypeError{x})
+		}
+		it = channelIterator(rv)
+	}
+
+	result := e.ValueOf(map[string]any{
+		"next": func(env Env, this Value, args []Value) any {
+			return it.next(env)
+		},
+		"return": func(env Env, this Value, args []Value) any {
+			it.cancel()
+
+			var value any
+			if len(args) > 0 {
+				value = args[0]
+			}
+			promise := env.NewPromise()
+			promise.Resolve(map[string]any{
+				"value": value,
+				"done":  true,
+			})
+			return promise
+		},
+	})
+
+	self := e.FuncOf(func(env Env, this Value, args []Value) any {
+		return this
+	})
+	key := e.Global().Get("Symbol").Get("asyncIterator")
+	st := napi.SetProperty(e.Env, result.Value, key.Value, self.Value.Value)
+	if st != napi.StatusOK {
+		panic(napi.NewError(e.Env, "SetProperty", st))
+	}
+
+	return result
+}
+
+// channelIterator returns an iterator over ch. Once cancelled, it stops
+// receiving from ch, including in a pending next().
+func channelIterator(ch reflect.Value) *asyncIterator {
+	done := make(chan struct{})
+	var doneOnce sync.Once
+
+	cases := []reflect.SelectCase{
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(done)},
+		{Dir: reflect.SelectRecv, Chan: ch},
+	}
+	return &asyncIterator{
+		recv: func() (any, bool, error) {
+			// Select picks randomly among ready cases, so done goes first
+			select {
+			case <-done:
+				return nil, false, nil
+			default:
+			}
+
+			chosen, v, ok := reflect.Select(cases)
+			if chosen == 0 || !ok {
+				return nil, false, nil
+			}
+			return v.Interface(), true, nil
+		},
+		cancel: func() {
+			doneOnce.Do(func() { close(done) })
+		},
+	}
+}
+
+func producerIterator(produce Producer) *asyncIterator {
+	ctx, cancel := context.WithCancel(context.Background())
+	values := make(chan any)
+	var err error
+
+	var startOnce sync.Once
+	start := func() {
+		go func() {
+			defer close(values)
+			defer func() {
+				if r := recover(); r != nil {
+					napi.ReportPanic(nil, "js.AsyncIteratorOf", r)
+					err = napi.PanicError{Value: r}
+				}
+			}()
+
+			err = produce(ctx, func(value any) bool {
+				select {
+				case values <- value:
+					return true
+				case <-ctx.Done():
+					return false
+				}
+			})
+		}()
+	}
+
+	return &asyncIterator{
+		recv: func() (any, bool, error) {
+			startOnce.Do(start)
+
+			v, ok := <-values
+			if !ok {
+				// report the producer's error only once
+				result := err
+				err = nil
+				return nil, false, result
+			}
+			return v, true, nil
+		},
+		cancel: cancel,
+	}
+}
+
+// next returns a promise for the next iterator result. Receives happen in the
+// order next was called, even though each runs on its own goroutine.
+func (it *asyncIterator) next(env Env) *Promise {
+	promise := env.NewPromise()
+
+	it.lock.Lock()
+	prev := it.tail
+	ready := make(chan struct{})
+	it.tail = ready
+	it.lock.Unlock()
+
+	go func() {
+		defer close(ready)
+		if prev != nil {
+			<-prev
+		}
+
+		value, ok, err := it.recv()
+		if err != nil {
+			promise.Reject(err)
+			return
+		}
+
+		promise.Resolve(map[string]any{
+			"value": value,
+			"done":  !ok,
+		})
+	}()
+
+	return promise
+}
@@ -0,0 +1,236 @@
+package js
+
+import (
+	"encoding"
+	"encoding/json"
+	"math"
+	"reflect"
+	"sync"
+	"sync/atomic"
+
+	"github.com/abhisekp/napi-go"
+)
+
+// DefaultJSONThreshold is the number of object properties, counted over all
+// nesting levels, from which ValueOf converts a slice or map by encoding it
+// with encoding/json and decoding it with JSON.parse, instead of creating
+// every value through Node-API. SetJSONThreshold changes it.
+//
+// Only object properties are counted, because setting them is what JSON.parse
+// does much faster than Node-API: the valueOf benchmarks of
+// docs/examples/bench show the JSON path ahead from about 15 properties and
+// close to twice as fast for large arrays of objects, while arrays of numbers
+// or strings are no faster through JSON at any size. The default leaves a
+// margin above that crossover for the cost of estimating the size.
+const DefaultJSONThreshold = 32
+
+var jsonThreshold atomic.Int64
+
+func init() {
+	jsonThreshold.Store(DefaultJSONThreshold)
+}
+
+// SetJSONThreshold sets the number of object properties from which ValueOf
+// converts slices and maps through JSON, for all envs. Setting it to 0
+// disables the fast path.
+func SetJSONThreshold(n int) {
+	jsonThreshold.Store(int64(n))
+}
+
+// JSONThreshold returns the threshold set with SetJSONThreshold.
+func JSONThreshold() int {
+	return int(jsonThreshold.Load())
+}
+
+var (
+	errorType         = reflect.TypeOf((*error)(nil)).Elem()
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// jsonSpecialTypes are converted by ValueOf in ways encoding/json does not
+// know about.
+var jsonSpecialTypes = map[reflect.Type]bool{
+	reflect.TypeOf(mapMarshaler{}):     true,
+	reflect.TypeOf(setMarshaler{}):     true,
+	reflect.TypeOf(methodsMarshaler{}): true,
+	reflect.TypeOf(Emitter{}):          true,
+	reflect.TypeOf(Promise{}):          true,
+}
+
+var jsonStructCache sync.Map // map[reflect.Type]bool
+
+// FromJSON parses data with the engine's JSON.parse.
+func (e Env) FromJSON(data []byte) (Value, error) {
+	var result Value
+	err := e.catch(func() {
+		result = e.Global().Get("JSON").Call("parse", string(data))
+	})
+	return result, err
+}
+
+// MarshalJSON serializes v with the engine's JSON.stringify. Values that
+// JSON.stringify does not serialize, such as undefined and functions, are
+// encoded as null.
+func (v Value) MarshalJSON() ([]byte, error) {
+	var result []byte
+	err := v.Env.catch(func() {
+		s := v.Env.Global().Get("JSON").Call("stringify", v)
+		if s.Type() != napi.ValueTypeString {
+			result = []byte("null")
+			return
+		}
+		result = []byte(s.String())
+	})
+	return result, err
+}
+
+// valueOfJSON converts x through JSON if it is a large slice or map that
+// encoding/json renders exactly like ValueOf would. The cost of x covers all of
+// its elements, so they need not be considered again if x is converted
+// through Node-API instead.
+func (e Env) valueOfJSON(x any) (Value, bool) {
+	threshold := JSONThreshold()
+	if threshold <= 0 {
+		return Value{}, false
+	}
+
+	rv := reflect.ValueOf(x)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Map:
+		if n, ok := jsonCost(rv); !ok || n < threshold {
+			return Value{}, false
+		}
+	default:
+		return Value{}, false
+	}
+
+	data, err := json.Marshal(x)
+	if err != nil {
+		return Value{}, false
+	}
+
+	result, err := e.FromJSON(data)
+	if err != nil {
+		return Value{}, false
+	}
+	return result, true
+}
+
+// jsonCost returns the number of object properties ValueOf sets to convert
+// rv, and reports whether encoding/json produces the same JS value for rv as
+// ValueOf does.
+func jsonCost(rv reflect.Value) (int, bool) {
+	t := rv.Type()
+	if t.Implements(errorType) ||
+		t.Implements(jsonMarshalerType) ||
+		t.Implements(textMarshalerType) ||
+		t.Implements(bindableType) ||
+		jsonSpecialTypes[t] {
+		return 0, false
+	}
+
+	// encoding/json also uses the pointer methods of addressable values, such
+	// as slice elements
+	pt := reflect.PointerTo(t)
+	if pt.Implements(jsonMarshalerType) || pt.Implements(textMarshalerType) {
+		return 0, false
+	}
+
+	switch rv.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return 0, true
+
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		return 0, !math.IsNaN(f) && !math.IsInf(f, 0)
+
+	case reflect.Pointer, reflect.Interface:
+		if rv.IsNil() {
+			return 0, true
+		}
+		return jsonCost(rv.Elem())
+
+	case reflect.Slice:
+		// encoding/json produces base64 strings where ValueOf produces Buffers
+		if t.Elem().Kind() == reflect.Uint8 {
+			return 0, false
+		}
+		fallthrough
+	case reflect.Array:
+		total := 0
+		for i, l := 0, rv.Len(); i < l; i++ {
+			n, ok := jsonCost(rv.Index(i))
+			if !ok {
+				return 0, false
+			}
+			total += n
+		}
+		return total, true
+
+	case reflect.Map:
+		switch t.Key().Kind() {
+		case reflect.String,
+			reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		default:
+			return 0, false
+		}
+
+		total := rv.Len()
+		iter := rv.MapRange()
+		for iter.Next() {
+			n, ok := jsonCost(iter.Value())
+			if !ok {
+				return 0, false
+			}
+			total += n
+		}
+		return total, true
+
+	case reflect.Struct:
+		if !jsonCompatibleStruct(t) {
+			return 0, false
+		}
+
+		total := 0
+		for _, f := range structFields(t) {
+			fv, ok := fieldByIndex(rv, f.Index)
+			if !ok {
+				continue
+			}
+			n, ok := jsonCost(fv)
+			if !ok {
+				return 0, false
+			}
+			total += 1 + n
+		}
+		return total, true
+	}
+
+	return 0, false
+}
+
+// jsonCompatibleStruct reports whether encoding/json names the fields of t
+// like ValueOf does, which is the case when neither js nor json tags are used.
+func jsonCompatibleStruct(t reflect.Type) bool {
+	if cached, ok := jsonStructCache.Load(t); ok {
+		return cached.(bool)
+	}
+
+	result := true
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		_, hasJS := sf.Tag.Lookup("js")
+		_, hasJSON := sf.Tag.Lookup("json")
+		if hasJS || hasJSON || (sf.Anonymous && !sf.IsExported()) {
+			result = false
+			break
+		}
+	}
+
+	jsonStructCache.Store(t, result)
+	return result
+}
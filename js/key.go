@@ -0,0 +1,98 @@
+package js
+
+import (
+	"sync"
+	"unicode/utf8"
+
+	"github.com/abhisekp/napi-go"
+)
+
+// maxKeys bounds the number of keys cached per env, so that Key cannot grow
+// without limit when called with arbitrary names.
+const maxKeys = 4096
+
+// keyCache holds the interned keys of an env. It is only used on the JS
+// thread of its env and therefore needs no lock. It holds plain references
+// rather than Refs, whose thread and deletion checks would make up most of
+// the cost of a cache hit.
+type keyCache struct {
+	keys map[string]napi.Reference
+}
+
+var keyCaches sync.Map // map[napi.Env]*keyCache
+
+// Key returns name as an internalized property key. Keys are cached per env,
+// so repeated lookups of the same name reuse the same JS string, which is
+// faster both to create and for V8 to look up than a regular string. Without
+// FeaturePropertyKeys, keys are cached as regular strings.
+func (e Env) Key(name string) Value {
+	cache := e.keyCache()
+	if ref, ok := cache.keys[name]; ok {
+		v, st := napi.GetReferenceValue(e.Env, ref)
+		if st != napi.StatusOK {
+			panic(napi.NewError(e.Env, "GetReferenceValue", st))
+		}
+		return Value{Env: e, Value: v}
+	}
+
+	var (
+		v  napi.Value
+		st napi.Status
+		fn string
+	)
+	switch {
+	case !e.Supports(FeaturePropertyKeys):
+		v, st = napi.CreateStringUtf8(e.Env, name)
+		fn = "CreateStringUtf8"
+	case isASCII(name):
+		v, st = napi.CreatePropertyKeyLatin1(e.Env, name)
+		fn = "CreatePropertyKeyLatin1"
+	default:
+		v, st = napi.CreatePropertyKeyUtf8(e.Env, name)
+		fn = "CreatePropertyKeyUtf8"
+	}
+	if st != napi.StatusOK {
+		panic(napi.NewError(e.Env, fn, st))
+	}
+
+	result := Value{Env: e, Value: v}
+	if len(cache.keys) < maxKeys {
+		ref, st := napi.CreateReference(e.Env, v, 1)
+		if st != napi.StatusOK {
+			panic(napi.NewError(e.Env, "CreateReference", st))
+		}
+		cache.keys[name] = ref
+	}
+	return result
+}
+
+// keyCache returns the key cache of e, registering a cleanup hook that drops
+// it and deletes its references on first use.
+func (e Env) keyCache() *keyCache {
+	if cache, ok := keyCaches.Load(e.Env); ok {
+		return cache.(*keyCache)
+	}
+
+	cache := &keyCache{keys: map[string]napi.Reference{}}
+	keyCaches.Store(e.Env, cache)
+
+	_, st := napi.AddEnvCleanupHook(e.Env, func() {
+		keyCaches.Delete(e.Env)
+		for _, ref := range cache.keys {
+			napi.DeleteReference(e.Env, ref)
+		}
+	})
+	if st != napi.StatusOK {
+		panic(napi.NewError(e.Env, "AddEnvCleanupHook", st))
+	}
+	return cache
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
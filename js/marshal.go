@@ -0,0 +1,282 @@
+package js
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/abhisekp/napi-go"
+)
+
+type mapMarshaler struct {
+	m any
+}
+
+type setMarshaler struct {
+	s any
+}
+
+// AsMap wraps the Go map m so that ValueOf converts it to a JS Map rather
+// than a plain object.
+func AsMap(m any) any {
+	return mapMarshaler{m}
+}
+
+// AsSet wraps the Go map or slice s so that ValueOf converts it to a JS Set
+// of the map's keys or the slice's elements.
+func AsSet(s any) any {
+	return setMarshaler{s}
+}
+
+// valueOfReflect converts the values ValueOf does not handle directly.
+//
+// Structs become objects whose properties are named after their exported
+// fields, which may be customised with a `js:"name,omitempty"` tag. The
+// options `map` and `set` in the tag apply AsMap and AsSet to the field.
+// Maps keyed by strings or integers become objects, maps with other keys
+// become JS Maps, and receivable channels become async iterators.
+func (e Env) valueOfReflect(x any, viaJSON bool) Value {
+	rv := reflect.ValueOf(x)
+	switch rv.Kind() {
+	case reflect.Bool:
+		return e.ValueOf(rv.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return e.ValueOf(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return e.ValueOf(rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		return e.ValueOf(rv.Float())
+	case reflect.String:
+		return e.ValueOf(rv.String())
+
+	case reflect.Pointer, reflect.Interface:
+		if rv.IsNil() {
+			return e.Null()
+		}
+		return e.valueOf(rv.Elem().Interface(), viaJSON)
+
+	case reflect.Slice:
+		if rv.IsNil() {
+			return e.Null()
+		}
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return e.ValueOf(rv.Bytes())
+		}
+		if viaJSON {
+			if result, ok := e.valueOfJSON(x); ok {
+				return result
+			}
+		}
+		return e.arrayOf(rv, false)
+	case reflect.Array:
+		return e.arrayOf(rv, viaJSON)
+
+	case reflect.Map:
+		if rv.IsNil() {
+			return e.Null()
+		}
+		if viaJSON {
+			if result, ok := e.valueOfJSON(x); ok {
+				return result
+			}
+		}
+		switch rv.Type().Key().Kind() {
+		case reflect.String,
+			reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return e.objectOfMap(rv, false)
+		}
+		return e.mapOf(rv, false)
+
+	case reflect.Struct:
+		return e.objectOfStruct(rv, viaJSON)
+
+	case reflect.Chan:
+		if rv.Type().ChanDir()&reflect.RecvDir != 0 {
+			return e.AsyncIteratorOf(x)
+		}
+	}
+
+	panic(InvalidValueTypeError{x})
+}
+
+func (e Env) arrayOf(rv reflect.Value, viaJSON bool) Value {
+	l := rv.Len()
+	v, st := napi.CreateArrayWithLength(e.Env, l)
+	if st != napi.StatusOK {
+		panic(napi.NewError(e.Env, "CreateArrayWithLength", st))
+	}
+
+	result := Value{Env: e, Value: v}
+	e.batched(l, func(i int) {
+		result.SetIndex(i, e.valueOf(rv.Index(i).Interface(), viaJSON))
+	})
+	return result
+}
+
+func (e Env) objectOfMap(rv reflect.Value, viaJSON bool) Value {
+	v, st := napi.CreateObject(e.Env)
+	if st != napi.StatusOK {
+		panic(napi.NewError(e.Env, "CreateObject", st))
+	}
+
+	result := Value{Env: e, Value: v}
+	iter := rv.MapRange()
+	e.batched(rv.Len(), func(int) {
+		iter.Next()
+		result.Set(mapKeyString(iter.Key()), e.valueOf(iter.Value().Interface(), viaJSON))
+	})
+	return result
+}
+
+func (e Env) mapOf(rv reflect.Value, viaJSON bool) Value {
+	result := e.Global().Get("Map").New()
+	iter := rv.MapRange()
+	e.batched(rv.Len(), func(int) {
+		iter.Next()
+		result.Call(
+			"set",
+			e.valueOf(iter.Key().Interface(), viaJSON),
+			e.valueOf(iter.Value().Interface(), viaJSON),
+		)
+	})
+	return result
+}
+
+func (e Env) setOf(rv reflect.Value, viaJSON bool) Value {
+	result := e.Global().Get("Set").New()
+	switch rv.Kind() {
+	case reflect.Map:
+		iter := rv.MapRange()
+		e.batched(rv.Len(), func(int) {
+			iter.Next()
+			result.Call("add", e.valueOf(iter.Key().Interface(), viaJSON))
+		})
+	case reflect.Slice, reflect.Array:
+		e.batched(rv.Len(), func(i int) {
+			result.Call("add", e.valueOf(rv.Index(i).Interface(), viaJSON))
+		})
+	default:
+		panic(InvalidValueTypeError{rv.Interface()})
+	}
+	return result
+}
+
+func (e Env) objectOfStruct(rv reflect.Value, viaJSON bool) Value {
+	v, st := napi.CreateObject(e.Env)
+	if st != napi.StatusOK {
+		panic(napi.NewError(e.Env, "CreateObject", st))
+	}
+
+	result := Value{Env: e, Value: v}
+	fields := structFields(rv.Type())
+	e.batched(len(fields), func(i int) {
+		f := fields[i]
+		fv, ok := fieldByIndex(rv, f.Index)
+		if !ok || (f.OmitEmpty && fv.IsZero()) {
+			return
+		}
+
+		var x any = fv.Interface()
+		switch {
+		case f.AsMap && fv.Kind() == reflect.Map:
+			x = AsMap(x)
+		case f.AsSet:
+			x = AsSet(x)
+		}
+		result.setKey(e.Key(f.Name), e.valueOf(x, viaJSON))
+	})
+	return result
+}
+
+func mapKeyString(k reflect.Value) string {
+	switch k.Kind() {
+	case reflect.String:
+		return k.String()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(k.Int(), 10)
+	default:
+		return strconv.FormatUint(k.Uint(), 10)
+	}
+}
+
+type structField struct {
+	Name      string
+	Index     []int
+	OmitEmpty bool
+	AsMap     bool
+	AsSet     bool
+}
+
+var structFieldCache sync.Map // map[reflect.Type][]structField
+
+// structFields returns the fields of t that are visible to JS, including the
+// fields of untagged embedded structs.
+func structFields(t reflect.Type) []structField {
+	if cached, ok := structFieldCache.Load(t); ok {
+		return cached.([]structField)
+	}
+
+	var result []structField
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag, tagged := sf.Tag.Lookup("js")
+		if tag == "-" || !sf.IsExported() {
+			continue
+		}
+
+		if sf.Anonymous && !tagged {
+			ft := sf.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				for _, f := range structFields(ft) {
+					f.Index = append([]int{i}, f.Index...)
+					result = append(result, f)
+				}
+				continue
+			}
+		}
+
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = sf.Name
+		}
+
+		f := structField{
+			Name:  name,
+			Index: []int{i},
+		}
+		for _, opt := range strings.Split(opts, ",") {
+			switch opt {
+			case "omitempty":
+				f.OmitEmpty = true
+			case "map":
+				f.AsMap = true
+			case "set":
+				f.AsSet = true
+			}
+		}
+		result = append(result, f)
+	}
+
+	structFieldCache.Store(t, result)
+	return result
+}
+
+// fieldByIndex is like reflect.Value.FieldByIndex, but reports false instead
+// of panicking when it encounters a nil embedded pointer.
+func fieldByIndex(rv reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && rv.Kind() == reflect.Pointer {
+			if rv.IsNil() {
+				return reflect.Value{}, false
+			}
+			rv = rv.Elem()
+		}
+		rv = rv.Field(x)
+	}
+	return rv, true
+}
@@ -0,0 +1,241 @@
+package js
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/abhisekp/napi-go"
+)
+
+type methodsMarshaler struct {
+	x any
+}
+
+// Bindable is implemented by types that ValueOf converts as if wrapped with
+// Methods.
+type Bindable interface {
+	// JSMethods returns the names of the methods to expose, or nil to
+	// expose all exported methods other than JSMethods.
+	JSMethods() []string
+}
+
+// ErrUnknownMethod is raised when Bindable.JSMethods names a method that
+// does not exist or is not exported.
+var ErrUnknownMethod = errors.New(
+	"Methods: type has no such exported method",
+)
+
+var (
+	envType      = reflect.TypeOf(Env{})
+	bindableType = reflect.TypeOf((*Bindable)(nil)).Elem()
+)
+
+// Methods wraps the Go value x, usually a pointer, so that ValueOf converts
+// it to a JS object whose methods call the exported methods of x. x is kept
+// alive until the object is garbage collected, and can be retrieved from it
+// with Unwrap. If x is not a pointer, the object holds a pointer to a copy.
+//
+// Arguments are decoded into the parameters of a method as by Decode, except
+// that parameters of type Env receive the env of the call and do not consume
+// an argument, and missing arguments are left zero. A method returning an
+// error throws it when it is not nil. The other results are returned through
+// ValueOf, as an array if there are several.
+//
+// The methods live on the prototype of a class that is created once per Go
+// type and env, so objects of the same type share them.
+func Methods(x any) any {
+	return methodsMarshaler{x}
+}
+
+// methodClassKey identifies the class of a type and set of methods.
+type methodClassKey struct {
+	t     reflect.Type
+	all   bool
+	names string
+}
+
+// methodClassCache holds the classes created by Methods in an env. It is
+// only used on the JS thread of its env and therefore needs no lock.
+type methodClassCache struct {
+	classes map[methodClassKey]*Ref
+}
+
+var methodClassCaches sync.Map // map[napi.Env]*methodClassCache
+
+// objectOfMethods converts x as described by Methods. names restricts the
+// methods to expose, as returned by Bindable.JSMethods.
+func (e Env) objectOfMethods(x any, names []string) Value {
+	rv := reflect.ValueOf(x)
+	if rv.Kind() != reflect.Pointer {
+		p := reflect.New(rv.Type())
+		p.Elem().Set(rv)
+		rv = p
+	} else if rv.IsNil() {
+		return e.Null()
+	}
+
+	class := e.methodClass(rv.Type(), names)
+	result := class.New()
+	result.wrap(rv.Interface(), typeTagFor(rv.Type().Elem()))
+	return result
+}
+
+// methodClass returns the class whose prototype holds the methods of the
+// pointer type t, defining it on first use.
+func (e Env) methodClass(t reflect.Type, names []string) Value {
+	cache := e.methodClassCache()
+	key := methodClassKey{t, names == nil, strings.Join(names, ",")}
+	if ref, ok := cache.classes[key]; ok {
+		return ref.Value()
+	}
+
+	methods := exposedMethods(t, names)
+	properties := make([]napi.PropertyDescriptor, len(methods))
+	for i, m := range methods {
+		properties[i] = napi.PropertyDescriptor{
+			Utf8name:   m.Name,
+			Method:     methodCallback(t, m),
+			Attributes: napi.DefaultMethod,
+		}
+	}
+
+	// Instances are only created by objectOfMethods, which wraps them after
+	// construction, so the constructor has nothing to do.
+	constructor := func(napi.Env, napi.CallbackInfo) napi.Value {
+		return nil
+	}
+
+	v, st := napi.DefineClass(e.Env, t.Elem().Name(), constructor, properties)
+	if st != napi.StatusOK {
+		panic(napi.NewError(e.Env, "DefineClass", st))
+	}
+
+	result := Value{Env: e, Value: v}
+	cache.classes[key] = e.NewRef(result)
+	return result
+}
+
+// methodClassCache returns the class cache of e, registering a cleanup hook
+// that drops it on first use.
+func (e Env) methodClassCache() *methodClassCache {
+	if cache, ok := methodClassCaches.Load(e.Env); ok {
+		return cache.(*methodClassCache)
+	}
+
+	cache := &methodClassCache{classes: map[methodClassKey]*Ref{}}
+	methodClassCaches.Store(e.Env, cache)
+
+	_, st := napi.AddEnvCleanupHook(e.Env, func() {
+		methodClassCaches.Delete(e.Env)
+	})
+	if st != napi.StatusOK {
+		panic(napi.NewError(e.Env, "AddEnvCleanupHook", st))
+	}
+	return cache
+}
+
+// exposedMethods returns the methods of t named by names, or all exported
+// methods if names is nil.
+func exposedMethods(t reflect.Type, names []string) []reflect.Method {
+	if names == nil {
+		result := make([]reflect.Method, 0, t.NumMethod())
+		for i := 0; i < t.NumMethod(); i++ {
+			m := t.Method(i)
+			if m.Name == "JSMethods" && t.Implements(bindableType) {
+				continue
+			}
+			result = append(result, m)
+		}
+		return result
+	}
+
+	result := make([]reflect.Method, len(names))
+	for i, name := range names {
+		m, ok := t.MethodByName(name)
+		if !ok {
+			panic(fmt.Errorf("%w: %s.%s", ErrUnknownMethod, t, name))
+		}
+		result[i] = m
+	}
+	return result
+}
+
+// methodCallback returns the callback that calls the method m of the value
+// of type t that is wrapped in this.
+func methodCallback(t reflect.Type, m reflect.Method) napi.Callback {
+	return AsCallback(func(env Env, this Value, args []Value) any {
+		x, err := this.unwrap(t.Elem())
+		if err != nil {
+			// unwrap has thrown a TypeError already
+			return env.Undefined()
+		}
+
+		in, err := methodArgs(env, m.Type, reflect.ValueOf(x), args)
+		if err != nil {
+			msg := fmt.Sprintf("%s.%s: %v", t.Elem().Name(), m.Name, err)
+			napi.ThrowTypeError(env.Env, "", msg)
+			return env.Undefined()
+		}
+
+		out := m.Func.Call(in)
+		if n := len(out); n > 0 && m.Type.Out(n-1) == errorType {
+			if err, _ := out[n-1].Interface().(error); err != nil {
+				st := napi.Throw(env.Env, env.ValueOf(err).Value)
+				if st != napi.StatusOK {
+					panic(napi.NewError(env.Env, "Throw", st))
+				}
+				return env.Undefined()
+			}
+			out = out[:n-1]
+		}
+
+		switch len(out) {
+		case 0:
+			return env.Undefined()
+		case 1:
+			return out[0].Interface()
+		}
+		results := make([]any, len(out))
+		for i, o := range out {
+			results[i] = o.Interface()
+		}
+		return results
+	})
+}
+
+// methodArgs decodes args into the arguments of a call of a method of type
+// mt with receiver recv.
+func methodArgs(env Env, mt reflect.Type, recv reflect.Value, args []Value) ([]reflect.Value, error) {
+	in := []reflect.Value{recv}
+	next := 0
+	for i := 1; i < mt.NumIn(); i++ {
+		pt := mt.In(i)
+		switch {
+		case pt == envType:
+			in = append(in, reflect.ValueOf(env))
+			continue
+		case mt.IsVariadic() && i == mt.NumIn()-1:
+			for ; next < len(args); next++ {
+				arg := reflect.New(pt.Elem())
+				if err := args[next].Decode(arg.Interface()); err != nil {
+					return nil, fmt.Errorf("argument %d: %w", next+1, err)
+				}
+				in = append(in, arg.Elem())
+			}
+			continue
+		}
+
+		arg := reflect.New(pt)
+		if next < len(args) {
+			if err := args[next].Decode(arg.Interface()); err != nil {
+				return nil, fmt.Errorf("argument %d: %w", next+1, err)
+			}
+		}
+		in = append(in, arg.Elem())
+		next++
+	}
+	return in, nil
+}
@@ -0,0 +1,18 @@
+package js
+
+import (
+	"github.com/abhisekp/napi-go"
+)
+
+// Require returns the Node.js builtin module with the given name, such as
+// "stream" or "events".
+func (e Env) Require(name string) Value {
+	process := e.Global().Get("process")
+	if process.Get("getBuiltinModule").Type() == napi.ValueTypeFunction {
+		return process.Call("getBuiltinModule", name)
+	}
+
+	// Node.js releases before getBuiltinModule only expose require through
+	// the main module
+	return process.Get("mainModule").Call("require", name)
+}
@@ -56,37 +56,44 @@ func (p *Promise) Reject(rejection any) {
 func (p *Promise) reset(e Env) {
 	np, st := napi.CreatePromise(e.Env)
 	if st != napi.StatusOK {
-		panic(napi.StatusError(st))
+		panic(napi.NewError(e.Env, "CreatePromise", st))
 	}
 
+	// the promise is settled in the async context it was created in
+	ctx := e.NewAsyncContext("napi-go/js-promise")
 	asyncResourceName := e.ValueOf("napi-go/js-promise")
 	fn := e.FuncOf(func(env Env, this Value, args []Value) any {
-		value := env.ValueOf(p.Result)
-
-		st := napi.StatusOK
-		switch p.ResultType {
-		case PromiseResultTypeResolved:
-			st = napi.ResolveDeferred(env.Env, p.Promise.Deferred, value.Value)
-		case PromiseResultTypeRejected:
-			st = napi.RejectDeferred(env.Env, p.Promise.Deferred, value.Value)
-		}
-
-		if st != napi.StatusOK {
-			panic(napi.StatusError(st))
-		}
-
+		defer ctx.Destroy()
+		ctx.Run(func(env Env) {
+			value := env.ValueOf(p.Result)
+
+			st, fn := napi.StatusOK, ""
+			switch p.ResultType {
+			case PromiseResultTypeResolved:
+				st = napi.ResolveDeferred(env.Env, p.Promise.Deferred, value.Value)
+				fn = "ResolveDeferred"
+			case PromiseResultTypeRejected:
+				st = napi.RejectDeferred(env.Env, p.Promise.Deferred, value.Value)
+				fn = "RejectDeferred"
+			}
+
+			if st != napi.StatusOK {
+				panic(napi.NewError(env.Env, fn, st))
+			}
+		})
 		return nil
 	})
 
 	tsFn, st := napi.CreateThreadsafeFunction(
 		e.Env,
 		fn.Value.Value,
-		nil, asyncResourceName.Value,
+		ctx.resource.Value().Value, asyncResourceName.Value,
 		0,
 		1, // initialize with 1 acquisition
 	)
 	if st != napi.StatusOK {
-		panic(napi.StatusError(st))
+		ctx.Destroy()
+		panic(napi.NewError(e.Env, "CreateThreadsafeFunction", st))
 	}
 
 	*p = Promise{
@@ -104,7 +111,7 @@ func (p *Promise) ensurePending() {
 func (p *Promise) settle() {
 	st := napi.CallThreadsafeFunction(p.ThreadsafeFunction, napi.Blocking)
 	if st != napi.StatusOK {
-		panic(napi.StatusError(st))
+		panic(napi.NewError(nil, "CallThreadsafeFunction", st))
 	}
 }
 
@@ -113,6 +120,6 @@ func (p *Promise) release() {
 	if st == napi.StatusClosing {
 		p.ThreadsafeFunction = nil
 	} else if st != napi.StatusOK {
-		panic(napi.StatusError(st))
+		panic(napi.NewError(nil, "ReleaseThreadsafeFunction", st))
 	}
 }
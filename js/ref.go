@@ -0,0 +1,169 @@
+package js
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/abhisekp/napi-go"
+)
+
+// Ref is a persistent handle to a JS value. Unlike Value, which is only
+// valid during the callback that produced it, a Ref may be stored and passed
+// between goroutines. Its value can only be accessed on the JS thread.
+//
+// A strong Ref keeps its value alive until Delete is called, while a weak Ref
+// lets it be garbage collected. All refs of an env are deleted automatically
+// when the env is torn down.
+type Ref struct {
+	env     Env
+	set     *refSet
+	lock    sync.Mutex
+	ref     napi.Reference
+	deleted bool
+}
+
+var (
+	ErrRefDeleted  = errors.New("Ref: reference has been deleted")
+	ErrNotJSThread = errors.New(
+		"Ref: value accessed outside of the JS thread",
+	)
+)
+
+type refSet struct {
+	// thread is the JS thread of the env, recorded so that refs can check
+	// where they are used without calling into Node-API
+	thread napi.Thread
+	lock   sync.Mutex
+	refs   map[*Ref]struct{}
+}
+
+var refSets sync.Map // map[napi.Env]*refSet
+
+// NewRef returns a strong reference to v.
+func (e Env) NewRef(v Value) *Ref {
+	return e.newRef(v, 1)
+}
+
+// NewWeakRef returns a weak reference to v. Once v has been garbage
+// collected, the Value of the ref is undefined.
+func (e Env) NewWeakRef(v Value) *Ref {
+	return e.newRef(v, 0)
+}
+
+func (e Env) newRef(v Value, refcount int) *Ref {
+	ref, st := napi.CreateReference(e.Env, v.Value, refcount)
+	if st != napi.StatusOK {
+		panic(napi.NewError(e.Env, "CreateReference", st))
+	}
+
+	result := &Ref{
+		env: e,
+		set: e.refSet(),
+		ref: ref,
+	}
+	result.set.add(result)
+	return result
+}
+
+// Value returns the referenced value, or undefined if the ref is weak and
+// the value has been garbage collected. It panics if called outside of the
+// JS thread or after Delete.
+func (r *Ref) Value() Value {
+	if !r.set.thread.IsCurrent() {
+		panic(ErrNotJSThread)
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.deleted {
+		panic(ErrRefDeleted)
+	}
+
+	v, st := napi.GetReferenceValue(r.env.Env, r.ref)
+	if st != napi.StatusOK {
+		panic(napi.NewError(r.env.Env, "GetReferenceValue", st))
+	}
+	if v == nil {
+		return r.env.Undefined()
+	}
+	return Value{
+		Env:   r.env,
+		Value: v,
+	}
+}
+
+// Delete releases the reference. It must be called on the JS thread and is a
+// no-op if the ref has already been deleted.
+func (r *Ref) Delete() {
+	if !r.set.thread.IsCurrent() {
+		panic(ErrNotJSThread)
+	}
+
+	if r.delete() {
+		r.set.remove(r)
+	}
+}
+
+func (r *Ref) delete() bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.deleted {
+		return false
+	}
+	r.deleted = true
+
+	st := napi.DeleteReference(r.env.Env, r.ref)
+	if st != napi.StatusOK {
+		panic(napi.NewError(r.env.Env, "DeleteReference", st))
+	}
+	return true
+}
+
+// refSet returns the refs of e, registering a cleanup hook that deletes them
+// on first use. Like every first use of a Ref, it runs on the JS thread of e,
+// which the set records.
+func (e Env) refSet() *refSet {
+	if set, ok := refSets.Load(e.Env); ok {
+		return set.(*refSet)
+	}
+
+	set, loaded := refSets.LoadOrStore(e.Env, &refSet{
+		thread: napi.CurrentThread(),
+		refs:   map[*Ref]struct{}{},
+	})
+	if !loaded {
+		_, st := napi.AddEnvCleanupHook(e.Env, func() {
+			refSets.Delete(e.Env)
+			set.(*refSet).deleteAll()
+		})
+		if st != napi.StatusOK {
+			panic(napi.NewError(e.Env, "AddEnvCleanupHook", st))
+		}
+	}
+	return set.(*refSet)
+}
+
+func (s *refSet) add(r *Ref) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.refs[r] = struct{}{}
+}
+
+func (s *refSet) remove(r *Ref) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.refs, r)
+}
+
+func (s *refSet) deleteAll() {
+	s.lock.Lock()
+	refs := s.refs
+	s.refs = map[*Ref]struct{}{}
+	s.lock.Unlock()
+
+	for r := range refs {
+		r.delete()
+	}
+}
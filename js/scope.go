@@ -0,0 +1,100 @@
+package js
+
+import (
+	"errors"
+
+	"github.com/abhisekp/napi-go"
+)
+
+// scopeBatch is the number of elements converted per handle scope when
+// ValueOf converts large slices and maps.
+const scopeBatch = 256
+
+// ErrScopeMismatch is raised when a handle scope is closed while a scope
+// opened inside of it is still open, which means that the inner scope leaked.
+var ErrScopeMismatch = errors.New(
+	"Scope: a handle scope opened inside of this scope was not closed",
+)
+
+// Scope calls fn inside a new handle scope. Values created by fn are released
+// when it returns and must not be used afterwards, unless they are kept in a
+// Ref. Use Scope in loops that create many temporary values.
+func (e Env) Scope(fn func(env Env)) {
+	scope, st := napi.OpenHandleScope(e.Env)
+	if st != napi.StatusOK {
+		panic(napi.NewError(e.Env, "OpenHandleScope", st))
+	}
+
+	done := false
+	defer func() {
+		st := napi.CloseHandleScope(e.Env, scope)
+		if done {
+			checkScopeClose(e, "CloseHandleScope", st)
+		}
+	}()
+
+	fn(e)
+	done = true
+}
+
+// EscapableScope is like Scope, but the Value returned by fn outlives the
+// scope and is returned to the caller.
+func (e Env) EscapableScope(fn func(env Env) Value) Value {
+	scope, st := napi.OpenEscapableHandleScope(e.Env)
+	if st != napi.StatusOK {
+		panic(napi.NewError(e.Env, "OpenEscapableHandleScope", st))
+	}
+
+	done := false
+	defer func() {
+		st := napi.CloseEscapableHandleScope(e.Env, scope)
+		if done {
+			checkScopeClose(e, "CloseEscapableHandleScope", st)
+		}
+	}()
+
+	v := fn(e)
+	result, st := napi.EscapeHandle(e.Env, scope, v.Value)
+	if st != napi.StatusOK {
+		panic(napi.NewError(e.Env, "EscapeHandle", st))
+	}
+
+	done = true
+	return Value{
+		Env:   e,
+		Value: result,
+	}
+}
+
+// checkScopeClose panics if closing a scope failed. It is only called when
+// the scope's function returned normally, so that it doesn't mask a panic.
+func checkScopeClose(e Env, fn string, st napi.Status) {
+	switch st {
+	case napi.StatusOK:
+	case napi.StatusHandleScopeMismatch:
+		panic(ErrScopeMismatch)
+	default:
+		panic(napi.NewError(e.Env, fn, st))
+	}
+}
+
+// batched calls fn for every i in [0, n). Large ranges are split into
+// batches of scopeBatch iterations that each run in their own handle scope,
+// so temporary values don't pile up until the callback returns.
+func (e Env) batched(n int, fn func(i int)) {
+	if n <= scopeBatch {
+		for i := 0; i < n; i++ {
+			fn(i)
+		}
+		return
+	}
+
+	for start := 0; start < n; start += scopeBatch {
+		end := min(start+scopeBatch, n)
+		e.Scope(func(Env) {
+			for i := start; i < end; i++ {
+				fn(i)
+			}
+		})
+	}
+}
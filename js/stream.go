@@ -0,0 +1,174 @@
+package js
+
+import (
+	"bytes"
+	"io"
+	"sync"
+)
+
+const readableChunkSize = 64 * 1024
+
+// ReadableFrom returns a Node.js Readable stream that yields the contents of
+// r. r is read on a separate goroutine, one chunk per read request from the
+// stream, so a slow consumer applies backpressure to r. If r implements
+// io.Closer, it is closed once the stream ends or is destroyed.
+func (e Env) ReadableFrom(r io.Reader) Value {
+	demand := make(chan struct{}, 1)
+	done := make(chan struct{})
+	var doneOnce, closeOnce sync.Once
+
+	// closing r also unblocks a pending Read, such as on a net.Conn or pipe
+	closeReader := func() {
+		closeOnce.Do(func() {
+			if c, ok := r.(io.Closer); ok {
+				c.Close()
+			}
+		})
+	}
+
+	var tsFn *ThreadsafeFunc
+	readable := e.Require("stream").Get("Readable").New(map[string]any{
+		"read": func(env Env, this Value, args []Value) any {
+			// keep the process alive only while a read is outstanding
+			tsFn.Ref(env)
+
+			select {
+			case demand <- struct{}{}:
+			default:
+			}
+			return nil
+		},
+		"destroy": func(env Env, this Value, args []Value) any {
+			doneOnce.Do(func() { close(done) })
+			// Close may block, so it must not run on the JS thread
+			go closeReader()
+			return args[1].Invoke(args[0])
+		},
+	})
+
+	tsFn = e.NewThreadsafeFunc("napi-go/js-readable", readable)
+	tsFn.Unref(e)
+
+	go func() {
+		defer tsFn.Release()
+		defer closeReader()
+
+		buf := make([]byte, readableChunkSize)
+		for {
+			select {
+			case <-done:
+				return
+			case <-demand:
+			}
+
+			n, err := r.Read(buf)
+			if n > 0 {
+				chunk := bytes.Clone(buf[:n])
+				callErr := tsFn.Call(func(env Env, this Value) {
+					tsFn.Unref(env)
+					this.Call("push", chunk)
+				})
+				if callErr != nil {
+					return
+				}
+			} else if err == nil {
+				// nothing was produced, so the stream is still waiting
+				select {
+				case demand <- struct{}{}:
+				default:
+				}
+			}
+
+			if err == io.EOF {
+				tsFn.Call(func(env Env, this Value) {
+					this.Call("push", nil)
+				})
+				return
+			} else if err != nil {
+				tsFn.Call(func(env Env, this Value) {
+					this.Call("destroy", err)
+				})
+				return
+			}
+		}
+	}()
+
+	return readable
+}
+
+type writer struct {
+	tsFn   *ThreadsafeFunc
+	lock   sync.Mutex
+	closed bool
+}
+
+var _ io.WriteCloser = &writer{}
+
+// AsWriter returns an io.WriteCloser that writes to the Node.js Writable
+// stream v. Each Write blocks until the stream has accepted the chunk, so a
+// slow stream applies backpressure to the writer. The writer may be used from
+// any goroutine except the JS thread, which it would deadlock.
+func (v Value) AsWriter() io.WriteCloser {
+	tsFn := v.Env.NewThreadsafeFunc("napi-go/js-writable", v)
+	return &writer{
+		tsFn: tsFn,
+	}
+}
+
+func (w *writer) Write(p []byte) (int, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if w.closed {
+		return 0, io.ErrClosedPipe
+	}
+
+	chunk := bytes.Clone(p)
+	if err := w.do("write", chunk); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (w *writer) Close() error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if w.closed {
+		return nil
+	}
+
+	w.closed = true
+	defer w.tsFn.Release()
+	return w.do("end")
+}
+
+// do calls the stream method m on the JS thread with a trailing completion
+// callback, and waits for that callback to run.
+func (w *writer) do(m string, args ...any) error {
+	result := make(chan error, 1)
+	err := w.tsFn.Call(func(env Env, this Value) {
+		cb := func(env Env, this Value, args []Value) any {
+			var err error
+			if len(args) > 0 {
+				err = args[0].AsError()
+			}
+			result <- err
+			return nil
+		}
+
+		if err := env.catch(func() {
+			this.Call(m, append(args, cb)...)
+		}); err != nil {
+			select {
+			case result <- err:
+			default: // the callback already reported
+			}
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	return <-result
+}
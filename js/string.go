@@ -0,0 +1,42 @@
+package js
+
+import (
+	"unicode/utf16"
+
+	"github.com/abhisekp/napi-go"
+)
+
+// ExternalString returns s as a JS string that references its characters
+// instead of copying them into the JS heap, which is useful for large strings
+// that live for the whole process, such as templates and embedded assets.
+// ASCII strings reference the bytes of s directly. Other strings are
+// converted to UTF-16 once, since V8 stores strings either as Latin-1 or as
+// UTF-16 and Go strings are UTF-8. The characters are kept alive until the JS
+// string is garbage collected. Without FeatureExternalStrings, s is copied as
+// with ValueOf.
+func (e Env) ExternalString(s string) Value {
+	if !e.Supports(FeatureExternalStrings) {
+		return e.ValueOf(s)
+	}
+
+	var (
+		v  napi.Value
+		st napi.Status
+	)
+	if isASCII(s) {
+		v, _, st = napi.CreateExternalStringLatin1(e.Env, s, nil, nil)
+		if st != napi.StatusOK {
+			panic(napi.NewError(e.Env, "CreateExternalStringLatin1", st))
+		}
+	} else {
+		str := utf16.Encode([]rune(s))
+		v, _, st = napi.CreateExternalStringUtf16(e.Env, str, nil, nil)
+		if st != napi.StatusOK {
+			panic(napi.NewError(e.Env, "CreateExternalStringUtf16", st))
+		}
+	}
+	return Value{
+		Env:   e,
+		Value: v,
+	}
+}
@@ -0,0 +1,85 @@
+package js
+
+import (
+	"errors"
+
+	"github.com/abhisekp/napi-go"
+)
+
+// Symbol is a JS symbol. It can be used as a property key with GetSymbol and
+// SetSymbol, and is passed through unchanged by ValueOf.
+type Symbol struct {
+	Value
+}
+
+// ErrUnknownSymbol is raised by WellKnownSymbol for names that are not
+// well-known symbols.
+var ErrUnknownSymbol = errors.New(
+	"WellKnownSymbol: Symbol has no such well-known symbol",
+)
+
+// NewSymbol returns a new symbol that is distinct from all other symbols,
+// like Symbol(description) in JS.
+func (e Env) NewSymbol(description string) Symbol {
+	desc := e.ValueOf(description)
+	v, st := napi.CreateSymbol(e.Env, desc.Value)
+	if st != napi.StatusOK {
+		panic(napi.NewError(e.Env, "CreateSymbol", st))
+	}
+	return Symbol{Value{Env: e, Value: v}}
+}
+
+// SymbolFor returns the symbol registered for key in the global symbol
+// registry, creating it if needed, like Symbol.for(key) in JS.
+func (e Env) SymbolFor(key string) Symbol {
+	if !e.Supports(FeatureSymbolFor) {
+		v := e.Global().Get("Symbol").Call("for", key)
+		return Symbol{v}
+	}
+
+	v, st := napi.SymbolFor(e.Env, key)
+	if st != napi.StatusOK {
+		panic(napi.NewError(e.Env, "SymbolFor", st))
+	}
+	return Symbol{Value{Env: e, Value: v}}
+}
+
+// WellKnownSymbol returns the well-known symbol Symbol[name], such as
+// "iterator" or "asyncIterator". It panics if there is no such symbol.
+func (e Env) WellKnownSymbol(name string) Symbol {
+	v := e.Global().Get("Symbol").Get(name)
+	if v.Type() != napi.ValueTypeSymbol {
+		panic(ErrUnknownSymbol)
+	}
+	return Symbol{v}
+}
+
+// Description returns the description of s, or "" if it has none.
+func (s Symbol) Description() string {
+	desc := s.Get("description")
+	if desc.IsUndefined() {
+		return ""
+	}
+	return desc.String()
+}
+
+// String returns s as Symbol(description). Unlike other values, symbols
+// cannot be converted to strings implicitly.
+func (s Symbol) String() string {
+	return s.Call("toString").String()
+}
+
+// IsSymbol reports whether v is a symbol.
+func (v Value) IsSymbol() bool {
+	return v.Type() == napi.ValueTypeSymbol
+}
+
+// GetSymbol returns the property of v keyed by sym.
+func (v Value) GetSymbol(sym Symbol) Value {
+	return v.getKey(sym.Value)
+}
+
+// SetSymbol sets the property of v keyed by sym.
+func (v Value) SetSymbol(sym Symbol, value any) {
+	v.setKey(sym.Value, value)
+}
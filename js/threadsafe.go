@@ -0,0 +1,138 @@
+package js
+
+import (
+	"sync"
+
+	"github.com/abhisekp/napi-go"
+)
+
+// ThreadsafeFunc runs Go functions on the JS thread on behalf of other
+// goroutines. Functions are invoked in the order they were queued, with the
+// receiver passed to NewThreadsafeFunc as this, inside the async context in
+// which the ThreadsafeFunc was created.
+type ThreadsafeFunc struct {
+	ThreadsafeFunction napi.ThreadsafeFunction
+
+	ctx     *AsyncContext
+	lock    sync.Mutex
+	pending []func(env Env, this Value)
+}
+
+// NewThreadsafeFunc creates a ThreadsafeFunc whose queued functions receive
+// this as their receiver. this is kept alive for as long as the
+// ThreadsafeFunc has not been released.
+func (e Env) NewThreadsafeFunc(name string, this Value) *ThreadsafeFunc {
+	result := &ThreadsafeFunc{
+		ctx: e.NewAsyncContext(name),
+	}
+
+	asyncResourceName := e.ValueOf(name)
+	fn := e.FuncOf(func(env Env, this Value, args []Value) any {
+		result.lock.Lock()
+		next := result.pending[0]
+		result.pending = result.pending[1:]
+		result.lock.Unlock()
+
+		// Release queues nil once no more functions can follow
+		if next == nil {
+			result.ctx.Destroy()
+			return nil
+		}
+
+		// there is no JS caller to throw to, so apply the panic policy
+		defer func() {
+			err := recover()
+			if err != nil {
+				napi.HandlePanic(env.Env, "js.ThreadsafeFunc", err)
+			}
+		}()
+
+		result.ctx.Run(func(env Env) {
+			next(env, this)
+		})
+		return nil
+	})
+
+	// bind the receiver so the threadsafe function retains it for us
+	bound := fn.Call("bind", this)
+
+	tsFn, st := napi.CreateThreadsafeFunction(
+		e.Env,
+		bound.Value,
+		result.ctx.resource.Value().Value, asyncResourceName.Value,
+		0,
+		1, // initialize with 1 acquisition
+	)
+	if st != napi.StatusOK {
+		result.ctx.Destroy()
+		panic(napi.NewError(e.Env, "CreateThreadsafeFunction", st))
+	}
+
+	result.ThreadsafeFunction = tsFn
+	return result
+}
+
+// Call queues fn to be run on the JS thread. It may be called from any
+// goroutine until Release.
+func (f *ThreadsafeFunc) Call(fn func(env Env, this Value)) error {
+	return f.queue(fn)
+}
+
+func (f *ThreadsafeFunc) queue(fn func(env Env, this Value)) error {
+	// hold the lock until fn is queued, so that pending stays in the order of
+	// the queue and fn can be dropped again if it was not queued
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.pending = append(f.pending, fn)
+	st := napi.CallThreadsafeFunction(f.ThreadsafeFunction, napi.Blocking)
+	if st != napi.StatusOK {
+		f.pending = f.pending[:len(f.pending)-1]
+		return napi.NewError(nil, "CallThreadsafeFunction", st)
+	}
+	return nil
+}
+
+// CallContext is like Call, but runs fn inside ctx rather than the context
+// in which f was created.
+func (f *ThreadsafeFunc) CallContext(
+	ctx *AsyncContext,
+	fn func(env Env, this Value),
+) error {
+	return f.Call(func(env Env, this Value) {
+		ctx.Run(func(env Env) {
+			fn(env, this)
+		})
+	})
+}
+
+// Release gives up the caller's acquisition of f. Functions already queued
+// still run; no further calls may be made afterwards.
+func (f *ThreadsafeFunc) Release() {
+	// the async context is no longer needed once the queued functions ran;
+	// if f is closing, the env is going away and takes it along
+	f.queue(nil)
+
+	st := napi.ReleaseThreadsafeFunction(f.ThreadsafeFunction, napi.Release)
+	if st != napi.StatusOK && st != napi.StatusClosing {
+		panic(napi.NewError(nil, "ReleaseThreadsafeFunction", st))
+	}
+}
+
+// Ref keeps the event loop alive while f is not released. It must be called
+// on the JS thread.
+func (f *ThreadsafeFunc) Ref(env Env) {
+	st := napi.RefThreadsafeFunction(env.Env, f.ThreadsafeFunction)
+	if st != napi.StatusOK {
+		panic(napi.NewError(env.Env, "RefThreadsafeFunction", st))
+	}
+}
+
+// Unref allows the event loop to exit while f is not released. It must be
+// called on the JS thread.
+func (f *ThreadsafeFunc) Unref(env Env) {
+	st := napi.UnrefThreadsafeFunction(env.Env, f.ThreadsafeFunction)
+	if st != napi.StatusOK {
+		panic(napi.NewError(env.Env, "UnrefThreadsafeFunction", st))
+	}
+}
@@ -12,3 +12,195 @@ type Value struct {
 func (v Value) GetEnv() Env {
 	return v.Env
 }
+
+func (v Value) Type() napi.ValueType {
+	vt, st := napi.Typeof(v.Env.Env, v.Value)
+	if st != napi.StatusOK {
+		panic(napi.NewError(v.Env.Env, "Typeof", st))
+	}
+	return vt
+}
+
+func (v Value) IsUndefined() bool {
+	return v.Type() == napi.ValueTypeUndefined
+}
+
+func (v Value) IsNull() bool {
+	return v.Type() == napi.ValueTypeNull
+}
+
+func (v Value) Get(key string) Value {
+	k := v.Env.ValueOf(key)
+	result, st := napi.GetProperty(v.Env.Env, v.Value, k.Value)
+	if st != napi.StatusOK {
+		panic(napi.NewError(v.Env.Env, "GetProperty", st))
+	}
+	return Value{
+		Env:   v.Env,
+		Value: result,
+	}
+}
+
+func (v Value) Set(key string, value any) {
+	k, x := v.Env.ValueOf(key), v.Env.ValueOf(value)
+	st := napi.SetProperty(v.Env.Env, v.Value, k.Value, x.Value)
+	if st != napi.StatusOK {
+		panic(napi.NewError(v.Env.Env, "SetProperty", st))
+	}
+}
+
+// getKey is like Get, but takes a key that has already been created, such as
+// one returned by Env.Key.
+func (v Value) getKey(k Value) Value {
+	result, st := napi.GetProperty(v.Env.Env, v.Value, k.Value)
+	if st != napi.StatusOK {
+		panic(napi.NewError(v.Env.Env, "GetProperty", st))
+	}
+	return Value{
+		Env:   v.Env,
+		Value: result,
+	}
+}
+
+// setKey is like Set, but takes a key that has already been created, such as
+// one returned by Env.Key.
+func (v Value) setKey(k Value, value any) {
+	x := v.Env.ValueOf(value)
+	st := napi.SetProperty(v.Env.Env, v.Value, k.Value, x.Value)
+	if st != napi.StatusOK {
+		panic(napi.NewError(v.Env.Env, "SetProperty", st))
+	}
+}
+
+func (v Value) Delete(key string) {
+	k := v.Env.ValueOf(key)
+	_, st := napi.DeleteProperty(v.Env.Env, v.Value, k.Value)
+	if st != napi.StatusOK {
+		panic(napi.NewError(v.Env.Env, "DeleteProperty", st))
+	}
+}
+
+func (v Value) Index(i int) Value {
+	result, st := napi.GetElement(v.Env.Env, v.Value, i)
+	if st != napi.StatusOK {
+		panic(napi.NewError(v.Env.Env, "GetElement", st))
+	}
+	return Value{
+		Env:   v.Env,
+		Value: result,
+	}
+}
+
+func (v Value) SetIndex(i int, value any) {
+	x := v.Env.ValueOf(value)
+	st := napi.SetElement(v.Env.Env, v.Value, i, x.Value)
+	if st != napi.StatusOK {
+		panic(napi.NewError(v.Env.Env, "SetElement", st))
+	}
+}
+
+func (v Value) Length() int {
+	return v.Get("length").Int()
+}
+
+// Call invokes the method m of v with v as the receiver.
+func (v Value) Call(m string, args ...any) Value {
+	return v.Get(m).call(v, args)
+}
+
+// Invoke calls v as a function with an undefined receiver.
+func (v Value) Invoke(args ...any) Value {
+	return v.call(v.Env.Undefined(), args)
+}
+
+// New calls v as a constructor.
+func (v Value) New(args ...any) Value {
+	argv := v.Env.valuesOf(args)
+	result, st := napi.NewInstance(v.Env.Env, v.Value, len(argv), argv)
+	if st != napi.StatusOK {
+		panic(napi.NewError(v.Env.Env, "NewInstance", st))
+	}
+	return Value{
+		Env:   v.Env,
+		Value: result,
+	}
+}
+
+func (v Value) InstanceOf(t Value) bool {
+	result, st := napi.InstanceOf(v.Env.Env, v.Value, t.Value)
+	if st != napi.StatusOK {
+		panic(napi.NewError(v.Env.Env, "InstanceOf", st))
+	}
+	return result
+}
+
+func (v Value) Equal(w Value) bool {
+	result, st := napi.StrictEquals(v.Env.Env, v.Value, w.Value)
+	if st != napi.StatusOK {
+		panic(napi.NewError(v.Env.Env, "StrictEquals", st))
+	}
+	return result
+}
+
+func (v Value) Bool() bool {
+	result, st := napi.GetValueBool(v.Env.Env, v.Value)
+	if st != napi.StatusOK {
+		panic(napi.NewError(v.Env.Env, "GetValueBool", st))
+	}
+	return result
+}
+
+// Truthy reports whether v is considered true by JS.
+func (v Value) Truthy() bool {
+	b, st := napi.CoerceToBool(v.Env.Env, v.Value)
+	if st != napi.StatusOK {
+		panic(napi.NewError(v.Env.Env, "CoerceToBool", st))
+	}
+	return Value{Env: v.Env, Value: b}.Bool()
+}
+
+func (v Value) Float() float64 {
+	result, st := napi.GetValueDouble(v.Env.Env, v.Value)
+	if st != napi.StatusOK {
+		panic(napi.NewError(v.Env.Env, "GetValueDouble", st))
+	}
+	return result
+}
+
+func (v Value) Int() int {
+	result, st := napi.GetValueInt64(v.Env.Env, v.Value)
+	if st != napi.StatusOK {
+		panic(napi.NewError(v.Env.Env, "GetValueInt64", st))
+	}
+	return int(result)
+}
+
+// String returns v converted to a string, as by the JS String function.
+func (v Value) String() string {
+	s := v.Value
+	if v.Type() != napi.ValueTypeString {
+		var st napi.Status
+		s, st = napi.CoerceToString(v.Env.Env, v.Value)
+		if st != napi.StatusOK {
+			panic(napi.NewError(v.Env.Env, "CoerceToString", st))
+		}
+	}
+
+	result, st := napi.GetValueStringUtf8(v.Env.Env, s)
+	if st != napi.StatusOK {
+		panic(napi.NewError(v.Env.Env, "GetValueStringUtf8", st))
+	}
+	return result
+}
+
+func (v Value) call(recv Value, args []any) Value {
+	argv := v.Env.valuesOf(args)
+	result, st := napi.CallFunction(v.Env.Env, recv.Value, v.Value, len(argv), argv)
+	if st != napi.StatusOK {
+		panic(napi.NewError(v.Env.Env, "CallFunction", st))
+	}
+	return Value{
+		Env:   v.Env,
+		Value: result,
+	}
+}
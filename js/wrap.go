@@ -0,0 +1,103 @@
+package js
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"reflect"
+	"runtime/cgo"
+	"sync"
+
+	"github.com/abhisekp/napi-go"
+)
+
+var typeTagCache sync.Map // map[reflect.Type]napi.TypeTag
+
+// Wrap attaches x to the JS object v and tags v with the Go type T, so that
+// Unwrap can later verify that v really holds a *T. x is kept alive until v
+// is garbage collected.
+func Wrap[T any](v Value, x *T) {
+	v.wrap(x, typeTagOf[T]())
+}
+
+// wrap attaches x to v and tags v with tag.
+func (v Value) wrap(x any, tag napi.TypeTag) {
+	h := cgo.NewHandle(x)
+	st := napi.WrapHandle(v.Env.Env, v.Value, h)
+	if st != napi.StatusOK {
+		h.Delete()
+		panic(napi.NewError(v.Env.Env, "WrapHandle", st))
+	}
+
+	st = napi.TypeTagObject(v.Env.Env, v.Value, tag)
+	if st != napi.StatusOK {
+		// a wrap that cannot be removed deletes h once v is collected
+		if napi.RemoveWrap(v.Env.Env, v.Value) == napi.StatusOK {
+			h.Delete()
+		}
+		panic(napi.NewError(v.Env.Env, "TypeTagObject", st))
+	}
+}
+
+// Unwrap returns the *T attached to v by Wrap. If v was not wrapped with a
+// value of type T, a TypeError is thrown in JS and returned as the error, and
+// the caller should return to JS without making further napi calls.
+func Unwrap[T any](v Value) (*T, error) {
+	x, err := v.unwrap(reflect.TypeOf((*T)(nil)).Elem())
+	if err != nil {
+		return nil, err
+	}
+	return x.(*T), nil
+}
+
+// unwrap returns the value attached to v by wrap, which must be tagged with
+// the type tag of t.
+func (v Value) unwrap(t reflect.Type) (any, error) {
+	ok := false
+	if vt := v.Type(); vt == napi.ValueTypeObject || vt == napi.ValueTypeFunction {
+		var st napi.Status
+		ok, st = napi.CheckObjectTypeTag(v.Env.Env, v.Value, typeTagFor(t))
+		if st != napi.StatusOK {
+			panic(napi.NewError(v.Env.Env, "CheckObjectTypeTag", st))
+		}
+	}
+
+	if !ok {
+		err := Error{
+			Name:    "TypeError",
+			Message: fmt.Sprintf("Unwrap: value is not a wrapped %s", t),
+		}
+		napi.ThrowTypeError(v.Env.Env, "", err.Message)
+		return nil, err
+	}
+
+	h, st := napi.UnwrapHandle(v.Env.Env, v.Value)
+	if st != napi.StatusOK {
+		panic(napi.NewError(v.Env.Env, "UnwrapHandle", st))
+	}
+	return h.Value(), nil
+}
+
+// typeTagOf returns the random type tag of T, which is unique to T within
+// the process.
+func typeTagOf[T any]() napi.TypeTag {
+	return typeTagFor(reflect.TypeOf((*T)(nil)).Elem())
+}
+
+// typeTagFor is like typeTagOf, but takes the reflect.Type of T.
+func typeTagFor(t reflect.Type) napi.TypeTag {
+	if cached, ok := typeTagCache.Load(t); ok {
+		return cached.(napi.TypeTag)
+	}
+
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(err)
+	}
+
+	tag, _ := typeTagCache.LoadOrStore(t, napi.TypeTag{
+		Lower: binary.LittleEndian.Uint64(b[:8]),
+		Upper: binary.LittleEndian.Uint64(b[8:]),
+	})
+	return tag.(napi.TypeTag)
+}
@@ -7,85 +7,104 @@ package napi
 import "C"
 
 import (
+	"sync"
 	"unsafe"
 )
 
 func GetUndefined(env Env) (Value, Status) {
+	debugCall(env, "GetUndefined")
 	var result Value
 	status := Status(C.napi_get_undefined(
 		C.napi_env(env),
 		(*C.napi_value)(unsafe.Pointer(&result)),
 	))
+	debugResult(env, result)
 	return result, status
 }
 
 func GetNull(env Env) (Value, Status) {
+	debugCall(env, "GetNull")
 	var result Value
 	status := Status(C.napi_get_null(
 		C.napi_env(env),
 		(*C.napi_value)(unsafe.Pointer(&result)),
 	))
+	debugResult(env, result)
 	return result, status
 }
 
 func GetGlobal(env Env) (Value, Status) {
+	debugCall(env, "GetGlobal")
 	var result Value
 	status := Status(C.napi_get_global(
 		C.napi_env(env),
 		(*C.napi_value)(unsafe.Pointer(&result)),
 	))
+	debugResult(env, result)
 	return result, status
 }
 
 func GetBoolean(env Env, value bool) (Value, Status) {
+	debugCall(env, "GetBoolean")
 	var result Value
 	status := Status(C.napi_get_boolean(
 		C.napi_env(env),
 		C.bool(value),
 		(*C.napi_value)(unsafe.Pointer(&result)),
 	))
+	debugResult(env, result)
 	return result, status
 }
 
 func CreateObject(env Env) (Value, Status) {
+	debugCall(env, "CreateObject")
 	var result Value
 	status := Status(C.napi_create_object(
 		C.napi_env(env),
 		(*C.napi_value)(unsafe.Pointer(&result)),
 	))
+	debugResult(env, result)
 	return result, status
 }
 
 func CreateArray(env Env) (Value, Status) {
+	debugCall(env, "CreateArray")
 	var result Value
 	status := Status(C.napi_create_array(
 		C.napi_env(env),
 		(*C.napi_value)(unsafe.Pointer(&result)),
 	))
+	debugResult(env, result)
 	return result, status
 }
 
 func CreateArrayWithLength(env Env, length int) (Value, Status) {
+	debugCall(env, "CreateArrayWithLength")
 	var result Value
 	status := Status(C.napi_create_array_with_length(
 		C.napi_env(env),
 		C.size_t(length),
 		(*C.napi_value)(unsafe.Pointer(&result)),
 	))
+	debugResult(env, result)
 	return result, status
 }
 
 func CreateDouble(env Env, value float64) (Value, Status) {
+	debugCall(env, "CreateDouble")
 	var result Value
 	status := Status(C.napi_create_double(
 		C.napi_env(env),
 		C.double(value),
 		(*C.napi_value)(unsafe.Pointer(&result)),
 	))
+	debugResult(env, result)
 	return result, status
 }
 
 func CreateStringUtf8(env Env, str string) (Value, Status) {
+	debugCall(env, "CreateStringUtf8")
+
 	cstr := C.CString(str)
 	defer C.free(unsafe.Pointer(cstr))
 
@@ -96,29 +115,37 @@ func CreateStringUtf8(env Env, str string) (Value, Status) {
 		C.size_t(len([]byte(str))), // must pass number of bytes
 		(*C.napi_value)(unsafe.Pointer(&result)),
 	))
+	debugResult(env, result)
 	return result, status
 }
 
 func CreateSymbol(env Env, description Value) (Value, Status) {
+	debugCall(env, "CreateSymbol", description)
 	var result Value
 	status := Status(C.napi_create_symbol(
 		C.napi_env(env),
 		C.napi_value(description),
 		(*C.napi_value)(unsafe.Pointer(&result)),
 	))
+	debugResult(env, result)
 	return result, status
 }
 
 func CreateFunction(env Env, name string, cb Callback) (Value, Status) {
+	debugCall(env, "CreateFunction")
+
 	provider, status := getInstanceData(env)
 	if status != StatusOK || provider == nil {
 		return nil, status
 	}
 
-	return provider.GetCallbackData().CreateCallback(env, name, cb)
+	result, status := provider.GetCallbackData().CreateCallback(env, name, cb)
+	debugResult(env, result)
+	return result, status
 }
 
 func CreateError(env Env, code, msg Value) (Value, Status) {
+	debugCall(env, "CreateError", code, msg)
 	var result Value
 	status := Status(C.napi_create_error(
 		C.napi_env(env),
@@ -126,10 +153,12 @@ func CreateError(env Env, code, msg Value) (Value, Status) {
 		C.napi_value(msg),
 		(*C.napi_value)(unsafe.Pointer(&result)),
 	))
+	debugResult(env, result)
 	return result, status
 }
 
 func Typeof(env Env, value Value) (ValueType, Status) {
+	debugCall(env, "Typeof", value)
 	var result ValueType
 	status := Status(C.napi_typeof(
 		C.napi_env(env),
@@ -140,6 +169,7 @@ func Typeof(env Env, value Value) (ValueType, Status) {
 }
 
 func GetValueDouble(env Env, value Value) (float64, Status) {
+	debugCall(env, "GetValueDouble", value)
 	var result float64
 	status := Status(C.napi_get_value_double(
 		C.napi_env(env),
@@ -150,6 +180,7 @@ func GetValueDouble(env Env, value Value) (float64, Status) {
 }
 
 func GetValueBool(env Env, value Value) (bool, Status) {
+	debugCall(env, "GetValueBool", value)
 	var result bool
 	status := Status(C.napi_get_value_bool(
 		C.napi_env(env),
@@ -159,49 +190,161 @@ func GetValueBool(env Env, value Value) (bool, Status) {
 	return result, status
 }
 
+// shortStringSize is the size of the pooled buffers used to read strings with
+// a single call. Longer strings are measured first and read into a buffer of
+// the right size.
+const shortStringSize = 256
+
+var (
+	byteBufPool = sync.Pool{
+		New: func() any { return new([shortStringSize]byte) },
+	}
+	uint16BufPool = sync.Pool{
+		New: func() any { return new([shortStringSize]uint16) },
+	}
+)
+
 func GetValueStringUtf8(env Env, value Value) (string, Status) {
-	// call napi_get_value_string_utf8 twice
-	// first is to get number of bytes
-	// second is to populate the actual string buffer
-	bufsize := C.size_t(0)
-	var strsize C.size_t
+	debugCall(env, "GetValueStringUtf8", value)
+
+	buf := byteBufPool.Get().(*[shortStringSize]byte)
+	defer byteBufPool.Put(buf)
 
+	var strsize C.size_t
 	status := Status(C.napi_get_value_string_utf8(
 		C.napi_env(env),
 		C.napi_value(value),
-		nil,
-		bufsize,
+		(*C.char)(unsafe.Pointer(&buf[0])),
+		C.size_t(len(buf)),
 		&strsize,
 	))
+	if status != StatusOK {
+		return "", status
+	}
 
+	// Node only writes whole characters, so a truncated string may leave up
+	// to 3 bytes unused besides the null terminator.
+	if int(strsize) < len(buf)-4 {
+		return string(buf[:strsize]), status
+	}
+
+	status = Status(C.napi_get_value_string_utf8(
+		C.napi_env(env),
+		C.napi_value(value),
+		nil,
+		0,
+		&strsize,
+	))
 	if status != StatusOK {
 		return "", status
 	}
 
 	// ensure there is room for the null terminator as well
-	strsize++
-	cstr := (*C.char)(C.malloc(C.sizeof_char * strsize))
-	defer C.free(unsafe.Pointer(cstr))
-
+	result := make([]byte, strsize+1)
 	status = Status(C.napi_get_value_string_utf8(
 		C.napi_env(env),
 		C.napi_value(value),
-		cstr,
-		strsize,
+		(*C.char)(unsafe.Pointer(&result[0])),
+		C.size_t(len(result)),
 		&strsize,
 	))
+	return string(result[:strsize]), status
+}
+
+// GetValueStringLatin1 reads value as a Latin-1 encoded string. Characters
+// outside of Latin-1 are truncated to their lower 8 bits.
+func GetValueStringLatin1(env Env, value Value) (string, Status) {
+	debugCall(env, "GetValueStringLatin1", value)
+
+	buf := byteBufPool.Get().(*[shortStringSize]byte)
+	defer byteBufPool.Put(buf)
 
+	var strsize C.size_t
+	status := Status(C.napi_get_value_string_latin1(
+		C.napi_env(env),
+		C.napi_value(value),
+		(*C.char)(unsafe.Pointer(&buf[0])),
+		C.size_t(len(buf)),
+		&strsize,
+	))
+	if status != StatusOK {
+		return "", status
+	}
+
+	if int(strsize) < len(buf)-1 {
+		return string(buf[:strsize]), status
+	}
+
+	status = Status(C.napi_get_value_string_latin1(
+		C.napi_env(env),
+		C.napi_value(value),
+		nil,
+		0,
+		&strsize,
+	))
 	if status != StatusOK {
 		return "", status
 	}
 
-	return C.GoStringN(
-		(*C.char)(cstr),
-		(C.int)(strsize),
-	), status
+	result := make([]byte, strsize+1)
+	status = Status(C.napi_get_value_string_latin1(
+		C.napi_env(env),
+		C.napi_value(value),
+		(*C.char)(unsafe.Pointer(&result[0])),
+		C.size_t(len(result)),
+		&strsize,
+	))
+	return string(result[:strsize]), status
+}
+
+// GetValueStringUtf16 reads value as UTF-16 code units.
+func GetValueStringUtf16(env Env, value Value) ([]uint16, Status) {
+	debugCall(env, "GetValueStringUtf16", value)
+
+	buf := uint16BufPool.Get().(*[shortStringSize]uint16)
+	defer uint16BufPool.Put(buf)
+
+	var strsize C.size_t
+	status := Status(C.napi_get_value_string_utf16(
+		C.napi_env(env),
+		C.napi_value(value),
+		(*C.char16_t)(unsafe.Pointer(&buf[0])),
+		C.size_t(len(buf)),
+		&strsize,
+	))
+	if status != StatusOK {
+		return nil, status
+	}
+
+	if int(strsize) < len(buf)-1 {
+		return append([]uint16(nil), buf[:strsize]...), status
+	}
+
+	status = Status(C.napi_get_value_string_utf16(
+		C.napi_env(env),
+		C.napi_value(value),
+		nil,
+		0,
+		&strsize,
+	))
+	if status != StatusOK {
+		return nil, status
+	}
+
+	result := make([]uint16, strsize+1)
+	status = Status(C.napi_get_value_string_utf16(
+		C.napi_env(env),
+		C.napi_value(value),
+		(*C.char16_t)(unsafe.Pointer(&result[0])),
+		C.size_t(len(result)),
+		&strsize,
+	))
+	return result[:strsize], status
 }
 
 func SetProperty(env Env, object, key, value Value) Status {
+	debugCall(env, "SetProperty", object, key, value)
+
 	return Status(C.napi_set_property(
 		C.napi_env(env),
 		C.napi_value(object),
@@ -211,6 +354,8 @@ func SetProperty(env Env, object, key, value Value) Status {
 }
 
 func SetElement(env Env, object Value, index int, value Value) Status {
+	debugCall(env, "SetElement", object, value)
+
 	return Status(C.napi_set_element(
 		C.napi_env(env),
 		C.napi_value(object),
@@ -220,6 +365,7 @@ func SetElement(env Env, object Value, index int, value Value) Status {
 }
 
 func StrictEquals(env Env, lhs, rhs Value) (bool, Status) {
+	debugCall(env, "StrictEquals", lhs, rhs)
 	var result bool
 	status := Status(C.napi_strict_equals(
 		C.napi_env(env),
@@ -235,48 +381,20 @@ type GetCbInfoResult struct {
 	This Value
 }
 
+// GetCbInfo returns the receiver and arguments of the call described by
+// info, which are also available as info.This and info.Args. Args is only
+// valid until the callback returns.
 func GetCbInfo(env Env, info CallbackInfo) (GetCbInfoResult, Status) {
-	// call napi_get_cb_info twice
-	// first is to get total number of arguments
-	// second is to populate the actual arguments
-	argc := C.size_t(0)
-	status := Status(C.napi_get_cb_info(
-		C.napi_env(env),
-		C.napi_callback_info(info),
-		&argc,
-		nil,
-		nil,
-		nil,
-	))
-
-	if status != StatusOK {
-		return GetCbInfoResult{}, status
-	}
-
-	argv := make([]Value, int(argc))
-	var cArgv unsafe.Pointer
-	if argc > 0 {
-		cArgv = unsafe.Pointer(&argv[0]) // must pass element pointer
-	}
-
-	var thisArg Value
-
-	status = Status(C.napi_get_cb_info(
-		C.napi_env(env),
-		C.napi_callback_info(info),
-		&argc,
-		(*C.napi_value)(cArgv),
-		(*C.napi_value)(unsafe.Pointer(&thisArg)),
-		nil,
-	))
-
+	debugCall(env, "GetCbInfo")
 	return GetCbInfoResult{
-		Args: argv,
-		This: thisArg,
-	}, status
+		Args: info.Args,
+		This: info.This,
+	}, StatusOK
 }
 
 func Throw(env Env, err Value) Status {
+	debugCall(env, "Throw", err)
+
 	return Status(C.napi_throw(
 		C.napi_env(env),
 		C.napi_value(err),
@@ -284,6 +402,8 @@ func Throw(env Env, err Value) Status {
 }
 
 func ThrowError(env Env, code, msg string) Status {
+	debugCall(env, "ThrowError")
+
 	codeCStr, msgCCstr := C.CString(code), C.CString(msg)
 	defer C.free(unsafe.Pointer(codeCStr))
 	defer C.free(unsafe.Pointer(msgCCstr))
@@ -296,16 +416,20 @@ func ThrowError(env Env, code, msg string) Status {
 }
 
 func CreatePromise(env Env) (Promise, Status) {
+	debugCall(env, "CreatePromise")
 	var result Promise
 	status := Status(C.napi_create_promise(
 		C.napi_env(env),
 		(*C.napi_deferred)(unsafe.Pointer(&result.Deferred)),
 		(*C.napi_value)(unsafe.Pointer(&result.Value)),
 	))
+	debugResult(env, result.Value)
 	return result, status
 }
 
 func ResolveDeferred(env Env, deferred Deferred, resolution Value) Status {
+	debugCall(env, "ResolveDeferred", resolution)
+
 	return Status(C.napi_resolve_deferred(
 		C.napi_env(env),
 		C.napi_deferred(deferred),
@@ -314,6 +438,8 @@ func ResolveDeferred(env Env, deferred Deferred, resolution Value) Status {
 }
 
 func RejectDeferred(env Env, deferred Deferred, rejection Value) Status {
+	debugCall(env, "RejectDeferred", rejection)
+
 	return Status(C.napi_reject_deferred(
 		C.napi_env(env),
 		C.napi_deferred(deferred),
@@ -322,6 +448,8 @@ func RejectDeferred(env Env, deferred Deferred, rejection Value) Status {
 }
 
 func SetInstanceData(env Env, data any) Status {
+	debugCall(env, "SetInstanceData")
+
 	provider, status := getInstanceData(env)
 	if status != StatusOK || provider == nil {
 		return status
@@ -332,6 +460,8 @@ func SetInstanceData(env Env, data any) Status {
 }
 
 func GetInstanceData(env Env) (any, Status) {
+	debugCall(env, "GetInstanceData")
+
 	provider, status := getInstanceData(env)
 	if status != StatusOK || provider == nil {
 		return nil, status
@@ -340,34 +470,52 @@ func GetInstanceData(env Env) (any, Status) {
 	return provider.GetUserData(), status
 }
 
+// CreateBuffer creates a Buffer of length bytes. The returned slice refers
+// to the memory of the Buffer and is only valid while the Buffer is alive.
 func CreateBuffer(env Env, length int) (Value, []byte, Status) {
+	debugCall(env, "CreateBuffer")
 	var result Value
-	data := make([]byte, length)
-	dataPtr := unsafe.Pointer(&data[0])
+	var data unsafe.Pointer
 
 	status := Status(C.napi_create_buffer(
 		C.napi_env(env),
 		C.size_t(length),
-		&dataPtr,
+		&data,
 		(*C.napi_value)(unsafe.Pointer(&result)),
 	))
-	return result, data, status
+	debugResult(env, result)
+	if status != StatusOK || data == nil {
+		return result, nil, status
+	}
+	return result, unsafe.Slice((*byte)(data), length), status
 }
 
 func CreateExternal(env Env, data unsafe.Pointer, finalize Finalize, finalizeHint unsafe.Pointer) (Value, Status) {
+	debugCall(env, "CreateExternal")
+
+	finalizer, entry, status := createFinalizer(env, finalize, finalizeHint)
+	if status != StatusOK {
+		return nil, status
+	}
+
 	var result Value
-	finalizer := FinalizeToFinalizer(finalize)
-	status := Status(C.napi_create_external(
+	status = Status(C.napi_create_external(
 		C.napi_env(env),
 		data,
-		C.napi_finalize(unsafe.Pointer(&finalizer)),
-		finalizeHint,
+		finalizer,
+		finalizerHint(entry),
 		(*C.napi_value)(unsafe.Pointer(&result)),
 	))
+
+	if status != StatusOK {
+		deleteFinalizer(env, entry)
+	}
+	debugResult(env, result)
 	return result, status
 }
 
 func GetValueInt32(env Env, value Value) (int32, Status) {
+	debugCall(env, "GetValueInt32", value)
 	var result C.int32_t
 	status := Status(C.napi_get_value_int32(
 		C.napi_env(env),
@@ -378,6 +526,7 @@ func GetValueInt32(env Env, value Value) (int32, Status) {
 }
 
 func GetValueUint32(env Env, value Value) (uint32, Status) {
+	debugCall(env, "GetValueUint32", value)
 	var result uint32
 	status := Status(C.napi_get_value_uint32(
 		C.napi_env(env),
@@ -388,6 +537,7 @@ func GetValueUint32(env Env, value Value) (uint32, Status) {
 }
 
 func GetValueInt64(env Env, value Value) (int64, Status) {
+	debugCall(env, "GetValueInt64", value)
 	var result int64
 	status := Status(C.napi_get_value_int64(
 		C.napi_env(env),
@@ -398,6 +548,7 @@ func GetValueInt64(env Env, value Value) (int64, Status) {
 }
 
 func GetValueBigIntInt64(env Env, value Value) (int64, bool, Status) {
+	debugCall(env, "GetValueBigIntInt64", value)
 	var result int64
 	var lossless bool
 	status := Status(C.napi_get_value_bigint_int64(
@@ -409,17 +560,34 @@ func GetValueBigIntInt64(env Env, value Value) (int64, bool, Status) {
 	return result, lossless, status
 }
 
-func GetValueBigIntWords(env Env, value Value, signBit int, wordCount int, words *uint64) Status {
-	return Status(C.napi_get_value_bigint_words(
+// GetValueBigIntWords reads the words of a BigInt into words, least
+// significant first, and returns its sign bit and word count. With an empty
+// words only the word count is returned.
+func GetValueBigIntWords(env Env, value Value, words []uint64) (int, int, Status) {
+	debugCall(env, "GetValueBigIntWords", value)
+	var signBit C.int
+	wordCount := C.size_t(len(words))
+
+	// Node only reports the word count if both are nil
+	var cSignBit *C.int
+	var cWords *C.uint64_t
+	if len(words) > 0 {
+		cSignBit = &signBit
+		cWords = (*C.uint64_t)(unsafe.Pointer(&words[0]))
+	}
+
+	status := Status(C.napi_get_value_bigint_words(
 		C.napi_env(env),
 		C.napi_value(value),
-		(*C.int)(unsafe.Pointer(&signBit)),
-		(*C.size_t)(unsafe.Pointer(&wordCount)),
-		(*C.uint64_t)(unsafe.Pointer(words)),
+		cSignBit,
+		&wordCount,
+		cWords,
 	))
+	return int(signBit), int(wordCount), status
 }
 
 func GetValueExternal(env Env, value Value) (unsafe.Pointer, Status) {
+	debugCall(env, "GetValueExternal", value)
 	var result unsafe.Pointer
 	status := Status(C.napi_get_value_external(
 		C.napi_env(env),
@@ -430,75 +598,89 @@ func GetValueExternal(env Env, value Value) (unsafe.Pointer, Status) {
 }
 
 func CoerceToBool(env Env, value Value) (Value, Status) {
+	debugCall(env, "CoerceToBool", value)
 	var result Value
 	status := Status(C.napi_coerce_to_bool(
 		C.napi_env(env),
 		C.napi_value(value),
 		(*C.napi_value)(unsafe.Pointer(&result)),
 	))
+	debugResult(env, result)
 	return result, status
 }
 
 func CoerceToNumber(env Env, value Value) (Value, Status) {
+	debugCall(env, "CoerceToNumber", value)
 	var result Value
 	status := Status(C.napi_coerce_to_number(
 		C.napi_env(env),
 		C.napi_value(value),
 		(*C.napi_value)(unsafe.Pointer(&result)),
 	))
+	debugResult(env, result)
 	return result, status
 }
 
 func CoerceToObject(env Env, value Value) (Value, Status) {
+	debugCall(env, "CoerceToObject", value)
 	var result Value
 	status := Status(C.napi_coerce_to_object(
 		C.napi_env(env),
 		C.napi_value(value),
 		(*C.napi_value)(unsafe.Pointer(&result)),
 	))
+	debugResult(env, result)
 	return result, status
 }
 
 func CoerceToString(env Env, value Value) (Value, Status) {
+	debugCall(env, "CoerceToString", value)
 	var result Value
 	status := Status(C.napi_coerce_to_string(
 		C.napi_env(env),
 		C.napi_value(value),
 		(*C.napi_value)(unsafe.Pointer(&result)),
 	))
+	debugResult(env, result)
 	return result, status
 }
 
 func CreateBufferCopy(env Env, data []byte) (Value, *byte, Status) {
+	debugCall(env, "CreateBufferCopy")
 	var result Value
-	var copiedData *byte
-	copiedDataPtr := unsafe.Pointer(&copiedData)
+	var copiedData unsafe.Pointer
+	var cData unsafe.Pointer
+	if len(data) > 0 {
+		cData = unsafe.Pointer(&data[0])
+	}
+
 	status := Status(C.napi_create_buffer_copy(
 		C.napi_env(env),
 		C.size_t(len(data)),
-		unsafe.Pointer(&data[0]),
-		&copiedDataPtr,
+		cData,
+		&copiedData,
 		(*C.napi_value)(unsafe.Pointer(&result)),
 	))
-	return result, copiedData, status
+	debugResult(env, result)
+	return result, (*byte)(copiedData), status
 }
 
 func GetBufferInfo(env Env, value Value) (*byte, int, Status) {
-	var data *byte
+	debugCall(env, "GetBufferInfo", value)
+	var data unsafe.Pointer
 	var length C.size_t
 
-	dataPtr := unsafe.Pointer(&data)
-
 	status := Status(C.napi_get_buffer_info(
 		C.napi_env(env),
 		C.napi_value(value),
-		&dataPtr,
+		&data,
 		&length,
 	))
-	return data, int(length), status
+	return (*byte)(data), int(length), status
 }
 
 func GetArrayLength(env Env, value Value) (int, Status) {
+	debugCall(env, "GetArrayLength", value)
 	var length C.uint32_t
 	status := Status(C.napi_get_array_length(
 		C.napi_env(env),
@@ -509,16 +691,19 @@ func GetArrayLength(env Env, value Value) (int, Status) {
 }
 
 func GetPrototype(env Env, value Value) (Value, Status) {
+	debugCall(env, "GetPrototype", value)
 	var result Value
 	status := Status(C.napi_get_prototype(
 		C.napi_env(env),
 		C.napi_value(value),
 		(*C.napi_value)(unsafe.Pointer(&result)),
 	))
+	debugResult(env, result)
 	return result, status
 }
 
 func InstanceOf(env Env, object, constructor Value) (bool, Status) {
+	debugCall(env, "InstanceOf", object, constructor)
 	var result bool
 	status := Status(C.napi_instanceof(
 		C.napi_env(env),
@@ -530,6 +715,7 @@ func InstanceOf(env Env, object, constructor Value) (bool, Status) {
 }
 
 func IsArray(env Env, value Value) (bool, Status) {
+	debugCall(env, "IsArray", value)
 	var result bool
 	status := Status(C.napi_is_array(
 		C.napi_env(env),
@@ -540,6 +726,7 @@ func IsArray(env Env, value Value) (bool, Status) {
 }
 
 func IsBuffer(env Env, value Value) (bool, Status) {
+	debugCall(env, "IsBuffer", value)
 	var result bool
 	status := Status(C.napi_is_buffer(
 		C.napi_env(env),
@@ -550,6 +737,7 @@ func IsBuffer(env Env, value Value) (bool, Status) {
 }
 
 func IsError(env Env, value Value) (bool, Status) {
+	debugCall(env, "IsError", value)
 	var result bool
 	status := Status(C.napi_is_error(
 		C.napi_env(env),
@@ -560,6 +748,7 @@ func IsError(env Env, value Value) (bool, Status) {
 }
 
 func IsPromise(env Env, value Value) (bool, Status) {
+	debugCall(env, "IsPromise", value)
 	var result bool
 	status := Status(C.napi_is_promise(
 		C.napi_env(env),
@@ -570,6 +759,7 @@ func IsPromise(env Env, value Value) (bool, Status) {
 }
 
 func IsTypedArray(env Env, value Value) (bool, Status) {
+	debugCall(env, "IsTypedArray", value)
 	var result bool
 	status := Status(C.napi_is_typedarray(
 		C.napi_env(env),
@@ -580,26 +770,28 @@ func IsTypedArray(env Env, value Value) (bool, Status) {
 }
 
 func GetTypedArrayInfo(env Env, value Value) (TypedArrayType, int, *byte, Value, int, Status) {
+	debugCall(env, "GetTypedArrayInfo", value)
 	var type_ TypedArrayType
 	var length C.size_t
-	var data *byte
+	var data unsafe.Pointer
 	var arrayBuffer Value
 	var byteOffset C.size_t
 
-	dataPtr := unsafe.Pointer(&data)
 	status := Status(C.napi_get_typedarray_info(
 		C.napi_env(env),
 		C.napi_value(value),
 		(*C.napi_typedarray_type)(unsafe.Pointer(&type_)),
 		&length,
-		&dataPtr,
+		&data,
 		(*C.napi_value)(unsafe.Pointer(&arrayBuffer)),
 		&byteOffset,
 	))
-	return type_, int(length), data, arrayBuffer, int(byteOffset), status
+	debugResult(env, arrayBuffer)
+	return type_, int(length), (*byte)(data), arrayBuffer, int(byteOffset), status
 }
 
 func CreateTypedArray(env Env, type_ TypedArrayType, length int, arrayBuffer Value, byteOffset int) (Value, Status) {
+	debugCall(env, "CreateTypedArray", arrayBuffer)
 	var result Value
 	status := Status(C.napi_create_typedarray(
 		C.napi_env(env),
@@ -609,10 +801,12 @@ func CreateTypedArray(env Env, type_ TypedArrayType, length int, arrayBuffer Val
 		C.size_t(byteOffset),
 		(*C.napi_value)(unsafe.Pointer(&result)),
 	))
+	debugResult(env, result)
 	return result, status
 }
 
 func AdjustExternalMemory(env Env, change int64) (int64, Status) {
+	debugCall(env, "AdjustExternalMemory")
 	var result int64
 	status := Status(C.napi_adjust_external_memory(
 		C.napi_env(env),
@@ -623,6 +817,7 @@ func AdjustExternalMemory(env Env, change int64) (int64, Status) {
 }
 
 func CreateDataView(env Env, length int, arrayBuffer Value, byteOffset int) (Value, Status) {
+	debugCall(env, "CreateDataView", arrayBuffer)
 	var result Value
 	status := Status(C.napi_create_dataview(
 		C.napi_env(env),
@@ -631,28 +826,31 @@ func CreateDataView(env Env, length int, arrayBuffer Value, byteOffset int) (Val
 		C.size_t(byteOffset),
 		(*C.napi_value)(unsafe.Pointer(&result)),
 	))
+	debugResult(env, result)
 	return result, status
 }
 
 func GetDataViewInfo(env Env, value Value) (int, *byte, Value, int, Status) {
+	debugCall(env, "GetDataViewInfo", value)
 	var length C.size_t
-	var data *byte
+	var data unsafe.Pointer
 	var arrayBuffer Value
 	var byteOffset C.size_t
 
-	dataPtr := unsafe.Pointer(&data)
 	status := Status(C.napi_get_dataview_info(
 		C.napi_env(env),
 		C.napi_value(value),
 		&length,
-		&dataPtr,
+		&data,
 		(*C.napi_value)(unsafe.Pointer(&arrayBuffer)),
 		&byteOffset,
 	))
-	return int(length), data, arrayBuffer, int(byteOffset), status
+	debugResult(env, arrayBuffer)
+	return int(length), (*byte)(data), arrayBuffer, int(byteOffset), status
 }
 
 func GetAllPropertyNames(env Env, object Value, keyMode KeyCollectionMode, keyFilter KeyFilter, keyConversion KeyConversion) (Value, Status) {
+	debugCall(env, "GetAllPropertyNames", object)
 	var result Value
 	status := Status(C.napi_get_all_property_names(
 		C.napi_env(env),
@@ -662,10 +860,12 @@ func GetAllPropertyNames(env Env, object Value, keyMode KeyCollectionMode, keyFi
 		C.napi_key_conversion(keyConversion),
 		(*C.napi_value)(unsafe.Pointer(&result)),
 	))
+	debugResult(env, result)
 	return result, status
 }
 
 func HasOwnProperty(env Env, object, key Value) (bool, Status) {
+	debugCall(env, "HasOwnProperty", object, key)
 	var result bool
 	status := Status(C.napi_has_own_property(
 		C.napi_env(env),
@@ -677,6 +877,7 @@ func HasOwnProperty(env Env, object, key Value) (bool, Status) {
 }
 
 func HasProperty(env Env, object, key Value) (bool, Status) {
+	debugCall(env, "HasProperty", object, key)
 	var result bool
 	status := Status(C.napi_has_property(
 		C.napi_env(env),
@@ -688,39 +889,69 @@ func HasProperty(env Env, object, key Value) (bool, Status) {
 }
 
 func GetPropertyNames(env Env, object Value) (Value, Status) {
+	debugCall(env, "GetPropertyNames", object)
 	var result Value
 	status := Status(C.napi_get_property_names(
 		C.napi_env(env),
 		C.napi_value(object),
 		(*C.napi_value)(unsafe.Pointer(&result)),
 	))
+	debugResult(env, result)
 	return result, status
 }
 
 func DefineProperties(env Env, object Value, properties []PropertyDescriptor) Status {
-	return Status(C.napi_define_properties(
-		C.napi_env(env),
-		C.napi_value(object),
-		C.size_t(len(properties)),
-		(*C.napi_property_descriptor)(unsafe.Pointer(&properties[0])),
-	))
+	debugCall(env, "DefineProperties", object)
+
+	provider, status := getInstanceData(env)
+	if status != StatusOK || provider == nil {
+		return status
+	}
+
+	return provider.GetCallbackData().DefineProperties(env, object, properties)
+}
+
+// DefineClass defines a JS class whose constructor calls constructor. The
+// Utf8name or Name of each property must be set, and properties with the
+// Static attribute are defined on the class rather than its prototype.
+func DefineClass(env Env, name string, constructor Callback, properties []PropertyDescriptor) (Value, Status) {
+	debugCall(env, "DefineClass")
+
+	provider, status := getInstanceData(env)
+	if status != StatusOK || provider == nil {
+		return nil, status
+	}
+
+	result, status := provider.GetCallbackData().DefineClass(env, name, constructor, properties)
+	debugResult(env, result)
+	return result, status
 }
 
-// Wrap function to use the Finalizer type internally
 func Wrap(env Env, jsObject Value, nativeObject unsafe.Pointer, finalize Finalize, finalizeHint unsafe.Pointer) Status {
-	var result Reference
-	status := Status(C.napi_wrap(
+	debugCall(env, "Wrap", jsObject)
+
+	finalizer, entry, status := createFinalizer(env, finalize, finalizeHint)
+	if status != StatusOK {
+		return status
+	}
+
+	status = Status(C.napi_wrap(
 		C.napi_env(env),
 		C.napi_value(jsObject),
 		nativeObject,
-		C.napi_finalize(unsafe.Pointer(&finalize)),
-		finalizeHint,
-		(*C.napi_ref)(unsafe.Pointer(&result)),
+		finalizer,
+		finalizerHint(entry),
+		nil,
 	))
+
+	if status != StatusOK {
+		deleteFinalizer(env, entry)
+	}
 	return status
 }
 
 func Unwrap(env Env, jsObject Value) (unsafe.Pointer, Status) {
+	debugCall(env, "Unwrap", jsObject)
 	var nativeObject unsafe.Pointer
 	status := Status(C.napi_unwrap(
 		C.napi_env(env),
@@ -731,6 +962,7 @@ func Unwrap(env Env, jsObject Value) (unsafe.Pointer, Status) {
 }
 
 func RemoveWrap(env Env, jsObject Value) Status {
+	debugCall(env, "RemoveWrap", jsObject)
 	var result unsafe.Pointer
 	return Status(C.napi_remove_wrap(
 		C.napi_env(env),
@@ -739,78 +971,133 @@ func RemoveWrap(env Env, jsObject Value) Status {
 	))
 }
 
+// AddFinalizer arranges for finalize to be called with finalizeData and
+// finalizeHint once jsObject is garbage collected. Unlike Wrap, any number of
+// finalizers can be added to an object, and they do not make finalizeData
+// retrievable from it.
+func AddFinalizer(env Env, jsObject Value, finalizeData unsafe.Pointer, finalize Finalize, finalizeHint unsafe.Pointer) Status {
+	debugCall(env, "AddFinalizer", jsObject)
+
+	finalizer, entry, status := createFinalizer(env, finalize, finalizeHint)
+	if status != StatusOK {
+		return status
+	}
+	if finalizer == nil {
+		return StatusInvalidArg
+	}
+
+	status = Status(C.napi_add_finalizer(
+		C.napi_env(env),
+		C.napi_value(jsObject),
+		finalizeData,
+		finalizer,
+		finalizerHint(entry),
+		nil,
+	))
+
+	if status != StatusOK {
+		deleteFinalizer(env, entry)
+	}
+	return status
+}
+
 func OpenHandleScope(env Env) (HandleScope, Status) {
+	debugCall(env, "OpenHandleScope")
 	var scope HandleScope
 	status := Status(C.napi_open_handle_scope(
 		C.napi_env(env),
-		(*C.napi_handle_scope)(unsafe.Pointer(&scope)),
+		(*C.napi_handle_scope)(unsafe.Pointer(&scope.Scope)),
 	))
+	if status == StatusOK {
+		debugOpenScope(env)
+	}
 	return scope, status
 }
 
 func CloseHandleScope(env Env, scope HandleScope) Status {
-	return Status(C.napi_close_handle_scope(
+	debugCall(env, "CloseHandleScope")
+	status := Status(C.napi_close_handle_scope(
 		C.napi_env(env),
-		C.napi_handle_scope(unsafe.Pointer(&scope)),
+		C.napi_handle_scope(scope.Scope),
 	))
+	if status == StatusOK {
+		debugCloseScope(env)
+	}
+	return status
 }
 
 func OpenEscapableHandleScope(env Env) (EscapableHandleScope, Status) {
+	debugCall(env, "OpenEscapableHandleScope")
 	var scope EscapableHandleScope
 	status := Status(C.napi_open_escapable_handle_scope(
 		C.napi_env(env),
-		(*C.napi_escapable_handle_scope)(unsafe.Pointer(&scope)),
+		(*C.napi_escapable_handle_scope)(unsafe.Pointer(&scope.Scope)),
 	))
+	if status == StatusOK {
+		debugOpenScope(env)
+	}
 	return scope, status
 }
 
 func CloseEscapableHandleScope(env Env, scope EscapableHandleScope) Status {
-	return Status(C.napi_close_escapable_handle_scope(
+	debugCall(env, "CloseEscapableHandleScope")
+	status := Status(C.napi_close_escapable_handle_scope(
 		C.napi_env(env),
-		C.napi_escapable_handle_scope(unsafe.Pointer(&scope)),
+		C.napi_escapable_handle_scope(scope.Scope),
 	))
+	if status == StatusOK {
+		debugCloseScope(env)
+	}
+	return status
 }
 
 func EscapeHandle(env Env, scope EscapableHandleScope, escapee Value) (Value, Status) {
+	debugCall(env, "EscapeHandle", escapee)
 	var result Value
 	status := Status(C.napi_escape_handle(
 		C.napi_env(env),
-		C.napi_escapable_handle_scope(unsafe.Pointer(&scope)),
+		C.napi_escapable_handle_scope(scope.Scope),
 		C.napi_value(escapee),
 		(*C.napi_value)(unsafe.Pointer(&result)),
 	))
+	debugEscape(env, result)
 	return result, status
 }
 
 func CreateReference(env Env, value Value, initialRefcount int) (Reference, Status) {
+	debugCall(env, "CreateReference", value)
 	var ref Reference
 	status := Status(C.napi_create_reference(
 		C.napi_env(env),
 		C.napi_value(value),
 		C.uint32_t(initialRefcount),
-		(*C.napi_ref)(unsafe.Pointer(&ref)),
+		(*C.napi_ref)(unsafe.Pointer(&ref.Ref)),
 	))
 	return ref, status
 }
 
 func DeleteReference(env Env, ref Reference) Status {
+	debugCall(env, "DeleteReference")
+
 	return Status(C.napi_delete_reference(
 		C.napi_env(env),
-		C.napi_ref(unsafe.Pointer(&ref)),
+		C.napi_ref(ref.Ref),
 	))
 }
 
 func ReferenceRef(env Env, ref Reference) (int, Status) {
+	debugCall(env, "ReferenceRef")
 	var result C.uint32_t
 	status := Status(C.napi_reference_ref(
 		C.napi_env(env),
-		C.napi_ref(unsafe.Pointer(&ref)),
+		C.napi_ref(ref.Ref),
 		&result,
 	))
 	return int(result), status
 }
 
 func ReferenceUnref(env Env, ref Reference) (int, Status) {
+	debugCall(env, "ReferenceUnref")
 	var result C.uint32_t
 	status := Status(C.napi_reference_unref(
 		C.napi_env(env),
@@ -821,16 +1108,19 @@ func ReferenceUnref(env Env, ref Reference) (int, Status) {
 }
 
 func GetReferenceValue(env Env, ref Reference) (Value, Status) {
+	debugCall(env, "GetReferenceValue")
 	var result Value
 	status := Status(C.napi_get_reference_value(
 		C.napi_env(env),
-		C.napi_ref(unsafe.Pointer(&ref)),
+		C.napi_ref(ref.Ref),
 		(*C.napi_value)(unsafe.Pointer(&result)),
 	))
+	debugResult(env, result)
 	return result, status
 }
 
 func GetValueBigIntUint64(env Env, value Value) (uint64, bool, Status) {
+	debugCall(env, "GetValueBigIntUint64", value)
 	var result uint64
 	var lossless bool
 	status := Status(C.napi_get_value_bigint_uint64(
@@ -843,26 +1133,31 @@ func GetValueBigIntUint64(env Env, value Value) (uint64, bool, Status) {
 }
 
 func CreateBigIntInt64(env Env, value int64) (Value, Status) {
+	debugCall(env, "CreateBigIntInt64")
 	var result Value
 	status := Status(C.napi_create_bigint_int64(
 		C.napi_env(env),
 		C.int64_t(value),
 		(*C.napi_value)(unsafe.Pointer(&result)),
 	))
+	debugResult(env, result)
 	return result, status
 }
 
 func CreateBigIntUint64(env Env, value uint64) (Value, Status) {
+	debugCall(env, "CreateBigIntUint64")
 	var result Value
 	status := Status(C.napi_create_bigint_uint64(
 		C.napi_env(env),
 		C.uint64_t(value),
 		(*C.napi_value)(unsafe.Pointer(&result)),
 	))
+	debugResult(env, result)
 	return result, status
 }
 
 func CreateBigIntWords(env Env, signBit int, wordCount int, words *uint64) (Value, Status) {
+	debugCall(env, "CreateBigIntWords")
 	var result Value
 	status := Status(C.napi_create_bigint_words(
 		C.napi_env(env),
@@ -871,10 +1166,12 @@ func CreateBigIntWords(env Env, signBit int, wordCount int, words *uint64) (Valu
 		(*C.uint64_t)(unsafe.Pointer(words)),
 		(*C.napi_value)(unsafe.Pointer(&result)),
 	))
+	debugResult(env, result)
 	return result, status
 }
 
 func IsDate(env Env, value Value) (bool, Status) {
+	debugCall(env, "IsDate", value)
 	var result bool
 	status := Status(C.napi_is_date(
 		C.napi_env(env),
@@ -885,6 +1182,7 @@ func IsDate(env Env, value Value) (bool, Status) {
 }
 
 func IsDetachedArrayBuffer(env Env, value Value) (bool, Status) {
+	debugCall(env, "IsDetachedArrayBuffer", value)
 	var result bool
 	status := Status(C.napi_is_detached_arraybuffer(
 		C.napi_env(env),
@@ -895,6 +1193,8 @@ func IsDetachedArrayBuffer(env Env, value Value) (bool, Status) {
 }
 
 func DetachArrayBuffer(env Env, value Value) Status {
+	debugCall(env, "DetachArrayBuffer", value)
+
 	return Status(C.napi_detach_arraybuffer(
 		C.napi_env(env),
 		C.napi_value(value),
@@ -902,46 +1202,59 @@ func DetachArrayBuffer(env Env, value Value) Status {
 }
 
 func CreateArrayBuffer(env Env, length int) (Value, *byte, Status) {
+	debugCall(env, "CreateArrayBuffer")
 	var result Value
-	var data *byte
-	dataPtr := unsafe.Pointer(&data)
+	var data unsafe.Pointer
 	status := Status(C.napi_create_arraybuffer(
 		C.napi_env(env),
 		C.size_t(length),
-		&dataPtr,
+		&data,
 		(*C.napi_value)(unsafe.Pointer(&result)),
 	))
-	return result, data, status
+	debugResult(env, result)
+	return result, (*byte)(data), status
 }
 
 func GetArrayBufferInfo(env Env, value Value) (*byte, int, Status) {
-	var data *byte
+	debugCall(env, "GetArrayBufferInfo", value)
+	var data unsafe.Pointer
 	var length C.size_t
-	dataPtr := unsafe.Pointer(&data)
 	status := Status(C.napi_get_arraybuffer_info(
 		C.napi_env(env),
 		C.napi_value(value),
-		&dataPtr,
+		&data,
 		&length,
 	))
-	return data, int(length), status
+	return (*byte)(data), int(length), status
 }
 
 func CreateExternalArrayBuffer(env Env, data unsafe.Pointer, length int, finalize Finalize, finalizeHint unsafe.Pointer) (Value, Status) {
+	debugCall(env, "CreateExternalArrayBuffer")
+
+	finalizer, entry, status := createFinalizer(env, finalize, finalizeHint)
+	if status != StatusOK {
+		return nil, status
+	}
+
 	var result Value
-	finalizer := FinalizeToFinalizer(finalize)
-	status := Status(C.napi_create_external_arraybuffer(
+	status = Status(C.napi_create_external_arraybuffer(
 		C.napi_env(env),
 		data,
 		C.size_t(length),
-		C.napi_finalize(unsafe.Pointer(&finalizer)),
-		finalizeHint,
+		finalizer,
+		finalizerHint(entry),
 		(*C.napi_value)(unsafe.Pointer(&result)),
 	))
+
+	if status != StatusOK {
+		deleteFinalizer(env, entry)
+	}
+	debugResult(env, result)
 	return result, status
 }
 
 func GetElement(env Env, object Value, index int) (Value, Status) {
+	debugCall(env, "GetElement", object)
 	var result Value
 	status := Status(C.napi_get_element(
 		C.napi_env(env),
@@ -949,10 +1262,12 @@ func GetElement(env Env, object Value, index int) (Value, Status) {
 		C.uint32_t(index),
 		(*C.napi_value)(unsafe.Pointer(&result)),
 	))
+	debugResult(env, result)
 	return result, status
 }
 
 func GetProperty(env Env, object, key Value) (Value, Status) {
+	debugCall(env, "GetProperty", object, key)
 	var result Value
 	status := Status(C.napi_get_property(
 		C.napi_env(env),
@@ -960,10 +1275,12 @@ func GetProperty(env Env, object, key Value) (Value, Status) {
 		C.napi_value(key),
 		(*C.napi_value)(unsafe.Pointer(&result)),
 	))
+	debugResult(env, result)
 	return result, status
 }
 
 func DeleteProperty(env Env, object, key Value) (bool, Status) {
+	debugCall(env, "DeleteProperty", object, key)
 	var result bool
 	status := Status(C.napi_delete_property(
 		C.napi_env(env),
@@ -975,6 +1292,8 @@ func DeleteProperty(env Env, object, key Value) (bool, Status) {
 }
 
 func SetNamedProperty(env Env, object Value, name string, value Value) Status {
+	debugCall(env, "SetNamedProperty", object, value)
+
 	cname := C.CString(name)
 	defer C.free(unsafe.Pointer(cname))
 	return Status(C.napi_set_named_property(
@@ -986,6 +1305,8 @@ func SetNamedProperty(env Env, object Value, name string, value Value) Status {
 }
 
 func GetNamedProperty(env Env, object Value, name string) (Value, Status) {
+	debugCall(env, "GetNamedProperty", object)
+
 	cname := C.CString(name)
 	defer C.free(unsafe.Pointer(cname))
 	var result Value
@@ -995,10 +1316,13 @@ func GetNamedProperty(env Env, object Value, name string) (Value, Status) {
 		cname,
 		(*C.napi_value)(unsafe.Pointer(&result)),
 	))
+	debugResult(env, result)
 	return result, status
 }
 
 func HasNamedProperty(env Env, object Value, name string) (bool, Status) {
+	debugCall(env, "HasNamedProperty", object)
+
 	cname := C.CString(name)
 	defer C.free(unsafe.Pointer(cname))
 	var result bool
@@ -1012,6 +1336,7 @@ func HasNamedProperty(env Env, object Value, name string) (bool, Status) {
 }
 
 func HasElement(env Env, object Value, index int) (bool, Status) {
+	debugCall(env, "HasElement", object)
 	var result bool
 	status := Status(C.napi_has_element(
 		C.napi_env(env),
@@ -1023,6 +1348,7 @@ func HasElement(env Env, object Value, index int) (bool, Status) {
 }
 
 func DeleteElement(env Env, object Value, index int) (bool, Status) {
+	debugCall(env, "DeleteElement", object)
 	var result bool
 	status := Status(C.napi_delete_element(
 		C.napi_env(env),
@@ -1034,6 +1360,8 @@ func DeleteElement(env Env, object Value, index int) (bool, Status) {
 }
 
 func ObjectFreeze(env Env, object Value) Status {
+	debugCall(env, "ObjectFreeze", object)
+
 	return Status(C.napi_object_freeze(
 		C.napi_env(env),
 		C.napi_value(object),
@@ -1041,13 +1369,48 @@ func ObjectFreeze(env Env, object Value) Status {
 }
 
 func ObjectSeal(env Env, object Value) Status {
+	debugCall(env, "ObjectSeal", object)
+
 	return Status(C.napi_object_seal(
 		C.napi_env(env),
 		C.napi_value(object),
 	))
 }
 
+func TypeTagObject(env Env, object Value, tag TypeTag) Status {
+	debugCall(env, "TypeTagObject", object)
+
+	cTag := C.napi_type_tag{
+		lower: C.uint64_t(tag.Lower),
+		upper: C.uint64_t(tag.Upper),
+	}
+	return Status(C.napi_type_tag_object(
+		C.napi_env(env),
+		C.napi_value(object),
+		&cTag,
+	))
+}
+
+func CheckObjectTypeTag(env Env, object Value, tag TypeTag) (bool, Status) {
+	debugCall(env, "CheckObjectTypeTag", object)
+
+	cTag := C.napi_type_tag{
+		lower: C.uint64_t(tag.Lower),
+		upper: C.uint64_t(tag.Upper),
+	}
+	var result bool
+	status := Status(C.napi_check_object_type_tag(
+		C.napi_env(env),
+		C.napi_value(object),
+		&cTag,
+		(*C.bool)(unsafe.Pointer(&result)),
+	))
+	return result, status
+}
+
 func ThrowTypeError(env Env, code, msg string) Status {
+	debugCall(env, "ThrowTypeError")
+
 	codeCStr, msgCCstr := C.CString(code), C.CString(msg)
 	defer C.free(unsafe.Pointer(codeCStr))
 	defer C.free(unsafe.Pointer(msgCCstr))
@@ -1060,6 +1423,8 @@ func ThrowTypeError(env Env, code, msg string) Status {
 }
 
 func ThrowRangeError(env Env, code, msg string) Status {
+	debugCall(env, "ThrowRangeError")
+
 	codeCStr, msgCCstr := C.CString(code), C.CString(msg)
 	defer C.free(unsafe.Pointer(codeCStr))
 	defer C.free(unsafe.Pointer(msgCCstr))
@@ -1072,6 +1437,7 @@ func ThrowRangeError(env Env, code, msg string) Status {
 }
 
 func CreateTypeError(env Env, code, msg Value) (Value, Status) {
+	debugCall(env, "CreateTypeError", code, msg)
 	var result Value
 	status := Status(C.napi_create_type_error(
 		C.napi_env(env),
@@ -1079,10 +1445,12 @@ func CreateTypeError(env Env, code, msg Value) (Value, Status) {
 		C.napi_value(msg),
 		(*C.napi_value)(unsafe.Pointer(&result)),
 	))
+	debugResult(env, result)
 	return result, status
 }
 
 func CreateRangeError(env Env, code, msg Value) (Value, Status) {
+	debugCall(env, "CreateRangeError", code, msg)
 	var result Value
 	status := Status(C.napi_create_range_error(
 		C.napi_env(env),
@@ -1090,10 +1458,12 @@ func CreateRangeError(env Env, code, msg Value) (Value, Status) {
 		C.napi_value(msg),
 		(*C.napi_value)(unsafe.Pointer(&result)),
 	))
+	debugResult(env, result)
 	return result, status
 }
 
 func IsExceptionPending(env Env) (bool, Status) {
+	debugCall(env, "IsExceptionPending")
 	var result bool
 	status := Status(C.napi_is_exception_pending(
 		C.napi_env(env),
@@ -1103,11 +1473,13 @@ func IsExceptionPending(env Env) (bool, Status) {
 }
 
 func GetAndClearLastException(env Env) (Value, Status) {
+	debugCall(env, "GetAndClearLastException")
 	var result Value
 	status := Status(C.napi_get_and_clear_last_exception(
 		C.napi_env(env),
 		(*C.napi_value)(unsafe.Pointer(&result)),
 	))
+	debugResult(env, result)
 	return result, status
 }
 
@@ -1117,53 +1489,77 @@ type CallbackScope struct {
 
 // CloseCallbackScope Function to close a callback scope
 func CloseCallbackScope(env Env, scope CallbackScope) Status {
+	debugCall(env, "CloseCallbackScope")
+
 	return Status(C.napi_close_callback_scope(
 		C.napi_env(env),
 		scope.scope,
 	))
 }
 
-// GetExtendedErrorInfo Function to retrieve extended error information
-func GetExtendedErrorInfo(env Env) (*C.napi_extended_error_info, Status) {
+// GetExtendedErrorInfo returns information about the last Node-API call on
+// env that failed. It must be called right after the failing call, since any
+// other call on env replaces the information.
+func GetExtendedErrorInfo(env Env) (ExtendedErrorInfo, Status) {
+	debugCall(env, "GetExtendedErrorInfo")
 	var errorInfo *C.napi_extended_error_info
 	status := Status(C.napi_get_last_error_info(
 		C.napi_env(env),
 		&errorInfo,
 	))
-	return errorInfo, status
+	if status != StatusOK || errorInfo == nil {
+		return ExtendedErrorInfo{}, status
+	}
+
+	result := ExtendedErrorInfo{
+		EngineErrorCode: uint32(errorInfo.engine_error_code),
+		Status:          Status(errorInfo.error_code),
+	}
+	if errorInfo.error_message != nil {
+		result.Message = C.GoString(errorInfo.error_message)
+	}
+	return result, status
 }
 
 func CreateInt32(env Env, value int32) (Value, Status) {
+	debugCall(env, "CreateInt32")
 	var result Value
 	status := Status(C.napi_create_int32(
 		C.napi_env(env),
 		C.int32_t(value),
 		(*C.napi_value)(unsafe.Pointer(&result)),
 	))
+	debugResult(env, result)
 	return result, status
 }
 
 func CreateUint32(env Env, value uint32) (Value, Status) {
+	debugCall(env, "CreateUint32")
 	var result Value
 	status := Status(C.napi_create_uint32(
 		C.napi_env(env),
 		C.uint32_t(value),
 		(*C.napi_value)(unsafe.Pointer(&result)),
 	))
+	debugResult(env, result)
 	return result, status
 }
 
 func CreateInt64(env Env, value int64) (Value, Status) {
+	debugCall(env, "CreateInt64")
 	var result Value
 	status := Status(C.napi_create_int64(
 		C.napi_env(env),
 		C.int64_t(value),
 		(*C.napi_value)(unsafe.Pointer(&result)),
 	))
+	debugResult(env, result)
 	return result, status
 }
 
 func CreateStringLatin1(env Env, str string) (Value, Status) {
+	debugCall(env, "CreateStringLatin1")
+
 	cstr := C.CString(str)
 	defer C.free(unsafe.Pointer(cstr))
 
@@ -1174,51 +1570,77 @@ func CreateStringLatin1(env Env, str string) (Value, Status) {
 		C.size_t(len([]byte(str))),
 		(*C.napi_value)(unsafe.Pointer(&result)),
 	))
+	debugResult(env, result)
 	return result, status
 }
 
 func CreateStringUtf16(env Env, str []uint16) (Value, Status) {
+	debugCall(env, "CreateStringUtf16")
+	var cstr *C.char16_t
+	if len(str) > 0 {
+		cstr = (*C.char16_t)(unsafe.Pointer(&str[0]))
+	}
+
 	var result Value
 	status := Status(C.napi_create_string_utf16(
 		C.napi_env(env),
-		(*C.char16_t)(unsafe.Pointer(&str[0])),
+		cstr,
 		C.size_t(len(str)),
 		(*C.napi_value)(unsafe.Pointer(&result)),
 	))
+	debugResult(env, result)
 	return result, status
 }
 
 func CallFunction(env Env, recv Value, fn Value, argc int, argv []Value) (Value, Status) {
+	debugCall(env, "CallFunction", recv, fn)
+	debugArgs(env, "CallFunction", argv)
+	var cArgv unsafe.Pointer
+	if argc > 0 {
+		cArgv = unsafe.Pointer(&argv[0]) // must pass element pointer
+	}
+
 	var result Value
 	status := Status(C.napi_call_function(
 		C.napi_env(env),
 		C.napi_value(recv),
 		C.napi_value(fn),
 		C.size_t(argc),
-		(*C.napi_value)(unsafe.Pointer(&argv[0])),
+		(*C.napi_value)(cArgv),
 		(*C.napi_value)(unsafe.Pointer(&result)),
 	))
+	debugResult(env, result)
 	return result, status
 }
 
 func GetNewTarget(env Env, info CallbackInfo) (Value, Status) {
+	debugCall(env, "GetNewTarget")
 	var result Value
 	status := Status(C.napi_get_new_target(
 		C.napi_env(env),
-		C.napi_callback_info(info),
+		C.napi_callback_info(info.info),
 		(*C.napi_value)(unsafe.Pointer(&result)),
 	))
+	debugResult(env, result)
 	return result, status
 }
 
 func NewInstance(env Env, constructor Value, argc int, argv []Value) (Value, Status) {
+	debugCall(env, "NewInstance", constructor)
+	debugArgs(env, "NewInstance", argv)
+	var cArgv unsafe.Pointer
+	if argc > 0 {
+		cArgv = unsafe.Pointer(&argv[0]) // must pass element pointer
+	}
+
 	var result Value
 	status := Status(C.napi_new_instance(
 		C.napi_env(env),
 		C.napi_value(constructor),
 		C.size_t(argc),
-		(*C.napi_value)(unsafe.Pointer(&argv[0])),
+		(*C.napi_value)(cArgv),
 		(*C.napi_value)(unsafe.Pointer(&result)),
 	))
+	debugResult(env, result)
 	return result, status
 }
@@ -0,0 +1,157 @@
+package napi
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"runtime"
+	"sync/atomic"
+)
+
+// PanicPolicy determines what happens to panics recovered outside of a JS
+// call frame, such as in async complete callbacks, finalizers and cleanup
+// hooks, where there is no caller to throw an exception to. Panics are logged
+// under every policy.
+type PanicPolicy int32
+
+const (
+	// PanicPolicyUncaughtException emits an uncaughtException in JS. Where JS
+	// cannot run, such as in finalizers and cleanup hooks, the panic is only
+	// logged.
+	PanicPolicyUncaughtException PanicPolicy = iota
+	// PanicPolicyLog only logs the panic.
+	PanicPolicyLog
+	// PanicPolicyAbort aborts the process with FatalError.
+	PanicPolicyAbort
+)
+
+var (
+	logger       atomic.Pointer[slog.Logger]
+	emitWarnings atomic.Bool
+	panicPolicy  atomic.Int32
+)
+
+// SetLogger sets the logger used for internal diagnostics, such as panics
+// recovered from Go callbacks. Stack traces are attached to the records as
+// the "stack" attribute. Passing nil restores the default logger, which
+// writes text records to stderr.
+func SetLogger(l *slog.Logger) {
+	logger.Store(l)
+}
+
+// Logger returns the logger set by SetLogger.
+func Logger() *slog.Logger {
+	if l := logger.Load(); l != nil {
+		return l
+	}
+	return defaultLogger
+}
+
+var defaultLogger = slog.New(slog.NewTextHandler(os.Stderr, nil))
+
+// SetEmitWarnings controls whether recovered panics are also reported to JS
+// with process.emitWarning, where possible. The warning has the type
+// "GoPanicWarning" and the stack trace as its detail.
+func SetEmitWarnings(enabled bool) {
+	emitWarnings.Store(enabled)
+}
+
+// SetPanicPolicy sets the policy for panics recovered outside of a JS call
+// frame. The default is PanicPolicyUncaughtException.
+func SetPanicPolicy(policy PanicPolicy) {
+	panicPolicy.Store(int32(policy))
+}
+
+// HandlePanic reports a panic recovered outside of a JS call frame and
+// applies the panic policy. env is nil if JS cannot run in the current
+// context.
+func HandlePanic(env Env, source string, err any) {
+	reportPanic(env, source, err)
+
+	msg := fmt.Sprint(err)
+	switch PanicPolicy(panicPolicy.Load()) {
+	case PanicPolicyUncaughtException:
+		if env == nil {
+			return
+		}
+
+		msgValue, status := CreateStringUtf8(env, msg)
+		if status != StatusOK {
+			return
+		}
+		errValue, status := CreateError(env, nil, msgValue)
+		if status != StatusOK {
+			return
+		}
+		FatalException(env, errValue)
+
+	case PanicPolicyAbort:
+		FatalError(source, msg)
+	}
+}
+
+// ReportPanic logs a panic recovered in source that is reported to JS by
+// other means, such as by rejecting a promise with a PanicError. Unlike
+// HandlePanic, it does not apply the panic policy. env is nil if JS cannot run
+// in the current context.
+func ReportPanic(env Env, source string, err any) {
+	reportPanic(env, source, err)
+}
+
+// reportPanic logs a panic recovered in source. If env is not nil, it must be
+// the env of the current JS thread and JS must be callable, in which case the
+// panic is also forwarded to process.emitWarning if enabled.
+func reportPanic(env Env, source string, err any) {
+	stackTraceBuf := make([]byte, maxStackTraceSize)
+	stackTraceSz := runtime.Stack(stackTraceBuf, false)
+	stack := string(stackTraceBuf[:stackTraceSz])
+
+	Logger().Error(
+		"Recovered from panic",
+		slog.String("source", source),
+		slog.Any("panic", err),
+		slog.String("stack", stack),
+	)
+
+	if env != nil && emitWarnings.Load() {
+		emitWarning(env, fmt.Sprintf("%s: %v", source, err), stack)
+	}
+}
+
+// emitWarning calls process.emitWarning, ignoring any failure since it is
+// only used while reporting another error.
+func emitWarning(env Env, msg, detail string) {
+	global, status := GetGlobal(env)
+	if status != StatusOK {
+		return
+	}
+	process, status := GetNamedProperty(env, global, "process")
+	if status != StatusOK {
+		return
+	}
+	fn, status := GetNamedProperty(env, process, "emitWarning")
+	if status != StatusOK {
+		return
+	}
+
+	options, status := CreateObject(env)
+	if status != StatusOK {
+		return
+	}
+	for k, v := range map[string]string{
+		"type":   "GoPanicWarning",
+		"detail": detail,
+	} {
+		value, status := CreateStringUtf8(env, v)
+		if status != StatusOK {
+			return
+		}
+		SetNamedProperty(env, options, k, value)
+	}
+
+	warning, status := CreateStringUtf8(env, msg)
+	if status != StatusOK {
+		return
+	}
+	CallFunction(env, process, fn, 2, []Value{warning, options})
+}
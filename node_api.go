@@ -3,10 +3,26 @@ package napi
 /*
 #include <stdlib.h>
 #include <node/node_api.h>
+
+extern void ExecuteEnvCleanupHook(void *arg);
+extern void ExecuteAsyncCleanupHook(
+	napi_async_cleanup_hook_handle handle,
+	void *arg
+);
+
+// Only declared by the headers of Node releases that provide it.
+extern napi_status node_api_create_buffer_from_arraybuffer(
+	napi_env env,
+	napi_value arraybuffer,
+	size_t byte_offset,
+	size_t byte_length,
+	napi_value *result
+);
 */
 import "C"
 
 import (
+	"runtime/cgo"
 	"unsafe"
 )
 
@@ -16,6 +32,8 @@ func CreateAsyncWork(
 	execute AsyncExecuteCallback,
 	complete AsyncCompleteCallback,
 ) (AsyncWork, Status) {
+	debugCall(env, "CreateAsyncWork", asyncResource, asyncResourceName)
+
 	provider, status := getInstanceData(env)
 	if status != StatusOK || provider == nil {
 		return AsyncWork{}, status
@@ -30,6 +48,8 @@ func CreateAsyncWork(
 }
 
 func DeleteAsyncWork(env Env, work AsyncWork) Status {
+	debugCall(env, "DeleteAsyncWork")
+
 	provider, status := getInstanceData(env)
 	if status != StatusOK || provider == nil {
 		return status
@@ -43,6 +63,8 @@ func DeleteAsyncWork(env Env, work AsyncWork) Status {
 }
 
 func QueueAsyncWork(env Env, work AsyncWork) Status {
+	debugCall(env, "QueueAsyncWork")
+
 	return Status(C.napi_queue_async_work(
 		C.napi_env(env),
 		C.napi_async_work(work.Handle),
@@ -50,13 +72,96 @@ func QueueAsyncWork(env Env, work AsyncWork) Status {
 }
 
 func CancelAsyncWork(env Env, work AsyncWork) Status {
+	debugCall(env, "CancelAsyncWork")
+
 	return Status(C.napi_cancel_async_work(
 		C.napi_env(env),
 		C.napi_async_work(work.Handle),
 	))
 }
 
+func AsyncInit(
+	env Env,
+	asyncResource, asyncResourceName Value,
+) (AsyncContext, Status) {
+	debugCall(env, "AsyncInit", asyncResource, asyncResourceName)
+	var result AsyncContext
+	status := Status(C.napi_async_init(
+		C.napi_env(env),
+		C.napi_value(asyncResource),
+		C.napi_value(asyncResourceName),
+		(*C.napi_async_context)(unsafe.Pointer(&result)),
+	))
+	return result, status
+}
+
+func AsyncDestroy(env Env, asyncContext AsyncContext) Status {
+	debugCall(env, "AsyncDestroy")
+
+	return Status(C.napi_async_destroy(
+		C.napi_env(env),
+		C.napi_async_context(asyncContext),
+	))
+}
+
+func MakeCallback(
+	env Env,
+	asyncContext AsyncContext,
+	recv, fn Value,
+	argc int,
+	argv []Value,
+) (Value, Status) {
+	debugCall(env, "MakeCallback", recv, fn)
+	debugArgs(env, "MakeCallback", argv)
+	var cArgv unsafe.Pointer
+	if argc > 0 {
+		cArgv = unsafe.Pointer(&argv[0]) // must pass element pointer
+	}
+
+	var result Value
+	status := Status(C.napi_make_callback(
+		C.napi_env(env),
+		C.napi_async_context(asyncContext),
+		C.napi_value(recv),
+		C.napi_value(fn),
+		C.size_t(argc),
+		(*C.napi_value)(cArgv),
+		(*C.napi_value)(unsafe.Pointer(&result)),
+	))
+	debugResult(env, result)
+	return result, status
+}
+
+func OpenCallbackScope(
+	env Env,
+	resourceObject Value,
+	asyncContext AsyncContext,
+) (CallbackScope, Status) {
+	debugCall(env, "OpenCallbackScope", resourceObject)
+	var scope CallbackScope
+	status := Status(C.napi_open_callback_scope(
+		C.napi_env(env),
+		C.napi_value(resourceObject),
+		C.napi_async_context(asyncContext),
+		(*C.napi_callback_scope)(unsafe.Pointer(&scope.scope)),
+	))
+	return scope, status
+}
+
+// GetVersion returns the highest Node-API version supported by the running
+// Node release.
+func GetVersion(env Env) (uint32, Status) {
+	debugCall(env, "GetVersion")
+	var result C.uint32_t
+	status := Status(C.napi_get_version(
+		C.napi_env(env),
+		&result,
+	))
+	return uint32(result), status
+}
+
 func GetNodeVersion(env Env) (NodeVersion, Status) {
+	debugCall(env, "GetNodeVersion")
 	var cresult *C.napi_node_version
 	status := Status(C.napi_get_node_version(
 		C.napi_env(env),
@@ -75,7 +180,157 @@ func GetNodeVersion(env Env) (NodeVersion, Status) {
 	}, status
 }
 
+// AddEnvCleanupHook registers hook to run on the JS thread when env is torn
+// down, in reverse order of registration.
+func AddEnvCleanupHook(env Env, hook func()) (CleanupHook, Status) {
+	debugCall(env, "AddEnvCleanupHook")
+
+	entry := cleanupHookData.CreateCleanupHook(hook)
+	status := Status(C.napi_add_env_cleanup_hook(
+		C.napi_env(env),
+		(*[0]byte)(C.ExecuteEnvCleanupHook),
+		entry.token,
+	))
+
+	if status != StatusOK {
+		cleanupHookData.DeleteCleanupHook(entry.ID)
+		return CleanupHook{}, status
+	}
+	return CleanupHook{ID: entry.ID}, status
+}
+
+func RemoveEnvCleanupHook(env Env, hook CleanupHook) Status {
+	debugCall(env, "RemoveEnvCleanupHook")
+
+	entry := cleanupHookData.GetCleanupHook(hook.ID)
+	if entry == nil {
+		return StatusInvalidArg
+	}
+
+	defer cleanupHookData.DeleteCleanupHook(hook.ID)
+	return Status(C.napi_remove_env_cleanup_hook(
+		C.napi_env(env),
+		(*[0]byte)(C.ExecuteEnvCleanupHook),
+		entry.token,
+	))
+}
+
+// AsyncCleanupHook identifies a hook added by AddAsyncCleanupHook.
+type AsyncCleanupHook struct {
+	handle C.napi_async_cleanup_hook_handle
+	token  unsafe.Pointer
+}
+
+// AddAsyncCleanupHook registers hook to run when env is torn down. Unlike
+// env cleanup hooks, teardown waits until hook, or an operation it starts,
+// removes itself with RemoveAsyncCleanupHook.
+func AddAsyncCleanupHook(env Env, hook func(AsyncCleanupHook)) (AsyncCleanupHook, Status) {
+	debugCall(env, "AddAsyncCleanupHook")
+
+	result := AsyncCleanupHook{token: newToken(cgo.NewHandle(hook))}
+	status := Status(C.napi_add_async_cleanup_hook(
+		C.napi_env(env),
+		(*[0]byte)(C.ExecuteAsyncCleanupHook),
+		result.token,
+		&result.handle,
+	))
+
+	if status != StatusOK {
+		(*(*cgo.Handle)(result.token)).Delete()
+		freeToken(result.token)
+		return AsyncCleanupHook{}, status
+	}
+	return result, status
+}
+
+// RemoveAsyncCleanupHook removes a hook added by AddAsyncCleanupHook, which
+// must not be used afterwards.
+func RemoveAsyncCleanupHook(hook AsyncCleanupHook) Status {
+	if hook.token == nil {
+		return StatusInvalidArg
+	}
+
+	status := Status(C.napi_remove_async_cleanup_hook(hook.handle))
+	(*(*cgo.Handle)(hook.token)).Delete()
+	freeToken(hook.token)
+	return status
+}
+
+//export ExecuteAsyncCleanupHook
+func ExecuteAsyncCleanupHook(handle C.napi_async_cleanup_hook_handle, arg unsafe.Pointer) {
+	defer func() {
+		err := recover()
+		if err != nil {
+			HandlePanic(nil, "napi.ExecuteAsyncCleanupHook", err)
+		}
+	}()
+
+	hook := (*(*cgo.Handle)(arg)).Value().(func(AsyncCleanupHook))
+	hook(AsyncCleanupHook{handle: handle, token: arg})
+}
+
+// GetUVEventLoop returns the libuv loop of env as a *uv_loop_t.
+func GetUVEventLoop(env Env) (unsafe.Pointer, Status) {
+	debugCall(env, "GetUVEventLoop")
+	var loop *C.struct_uv_loop_s
+	status := Status(C.napi_get_uv_event_loop(
+		C.napi_env(env),
+		&loop,
+	))
+	return unsafe.Pointer(loop), status
+}
+
+// CreateExternalBuffer creates a Buffer backed by length bytes at data, which
+// must stay valid until finalize is called.
+func CreateExternalBuffer(env Env, length int, data unsafe.Pointer, finalize Finalize, finalizeHint unsafe.Pointer) (Value, Status) {
+	debugCall(env, "CreateExternalBuffer")
+
+	finalizer, entry, status := createFinalizer(env, finalize, finalizeHint)
+	if status != StatusOK {
+		return nil, status
+	}
+
+	var result Value
+	status = Status(C.napi_create_external_buffer(
+		C.napi_env(env),
+		C.size_t(length),
+		data,
+		finalizer,
+		finalizerHint(entry),
+		(*C.napi_value)(unsafe.Pointer(&result)),
+	))
+
+	if status != StatusOK {
+		deleteFinalizer(env, entry)
+	}
+	debugResult(env, result)
+	return result, status
+}
+
+// FatalError aborts the process immediately with msg, reported as happening
+// at location. It does not return.
+func FatalError(location, msg string) {
+	locationCStr, msgCStr := C.CString(location), C.CString(msg)
+	C.napi_fatal_error(
+		locationCStr,
+		C.size_t(len(location)),
+		msgCStr,
+		C.size_t(len(msg)),
+	)
+}
+
+// FatalException triggers an uncaughtException in JS with err.
+func FatalException(env Env, err Value) Status {
+	debugCall(env, "FatalException", err)
+
+	return Status(C.napi_fatal_exception(
+		C.napi_env(env),
+		C.napi_value(err),
+	))
+}
+
 func GetModuleFileName(env Env) (string, Status) {
+	debugCall(env, "GetModuleFileName")
 	var cresult *C.char
 	status := Status(C.node_api_get_module_file_name(
 		C.napi_env(env),
@@ -95,6 +350,7 @@ func CreateThreadsafeFunction(
 	asyncResource, asyncResourceName Value,
 	maxQueueSize, initialThreadCount int,
 ) (ThreadsafeFunction, Status) {
+	debugCall(env, "CreateThreadsafeFunction", fn, asyncResource, asyncResourceName)
 	var result ThreadsafeFunction
 	status := Status(C.napi_create_threadsafe_function(
 		C.napi_env(env),
@@ -151,6 +407,8 @@ func GetThreadsafeFunctionContext(
 }
 
 func RefThreadsafeFunction(env Env, fn ThreadsafeFunction) Status {
+	debugCall(env, "RefThreadsafeFunction")
+
 	return Status(C.napi_ref_threadsafe_function(
 		C.napi_env(env),
 		C.napi_threadsafe_function(fn),
@@ -158,6 +416,8 @@ func RefThreadsafeFunction(env Env, fn ThreadsafeFunction) Status {
 }
 
 func UnrefThreadsafeFunction(env Env, fn ThreadsafeFunction) Status {
+	debugCall(env, "UnrefThreadsafeFunction")
+
 	return Status(C.napi_unref_threadsafe_function(
 		C.napi_env(env),
 		C.napi_threadsafe_function(fn),
@@ -165,6 +425,8 @@ func UnrefThreadsafeFunction(env Env, fn ThreadsafeFunction) Status {
 }
 
 func ThrowSyntaxError(env Env, code, msg string) Status {
+	debugCall(env, "ThrowSyntaxError")
+
 	codeCStr, msgCStr := C.CString(code), C.CString(msg)
 	defer C.free(unsafe.Pointer(codeCStr))
 	defer C.free(unsafe.Pointer(msgCStr))
@@ -177,6 +439,7 @@ func ThrowSyntaxError(env Env, code, msg string) Status {
 }
 
 func CreateSyntaxError(env Env, code, msg Value) (Value, Status) {
+	debugCall(env, "CreateSyntaxError", code, msg)
 	var result Value
 	status := Status(C.node_api_create_syntax_error(
 		C.napi_env(env),
@@ -184,21 +447,30 @@ func CreateSyntaxError(env Env, code, msg Value) (Value, Status) {
 		C.napi_value(msg),
 		(*C.napi_value)(unsafe.Pointer(&result)),
 	))
+	debugResult(env, result)
 	return result, status
 }
 
 func SymbolFor(env Env, description string) (Value, Status) {
+	debugCall(env, "SymbolFor")
+
+	cstr := C.CString(description)
+	defer C.free(unsafe.Pointer(cstr))
+
 	var result Value
 	status := Status(C.node_api_symbol_for(
 		C.napi_env(env),
-		C.CString(description),
+		cstr,
 		C.size_t(len(description)),
 		(*C.napi_value)(unsafe.Pointer(&result)),
 	))
+	debugResult(env, result)
 	return result, status
 }
 
 func CreatePropertyKeyLatin1(env Env, str string) (Value, Status) {
+	debugCall(env, "CreatePropertyKeyLatin1")
+
 	cstr := C.CString(str)
 	defer C.free(unsafe.Pointer(cstr))
 
@@ -209,21 +481,31 @@ func CreatePropertyKeyLatin1(env Env, str string) (Value, Status) {
 		C.size_t(len([]byte(str))),
 		(*C.napi_value)(unsafe.Pointer(&result)),
 	))
+	debugResult(env, result)
 	return result, status
 }
 
 func CreatePropertyKeyUtf16(env Env, str []uint16) (Value, Status) {
+	debugCall(env, "CreatePropertyKeyUtf16")
+	var cstr *C.char16_t
+	if len(str) > 0 {
+		cstr = (*C.char16_t)(unsafe.Pointer(&str[0]))
+	}
+
 	var result Value
 	status := Status(C.node_api_create_property_key_utf16(
 		C.napi_env(env),
-		(*C.char16_t)(unsafe.Pointer(&str[0])),
+		cstr,
 		C.size_t(len(str)),
 		(*C.napi_value)(unsafe.Pointer(&result)),
 	))
+	debugResult(env, result)
 	return result, status
 }
 
 func CreatePropertyKeyUtf8(env Env, str string) (Value, Status) {
+	debugCall(env, "CreatePropertyKeyUtf8")
+
 	cstr := C.CString(str)
 	defer C.free(unsafe.Pointer(cstr))
 
@@ -234,5 +516,47 @@ func CreatePropertyKeyUtf8(env Env, str string) (Value, Status) {
 		C.size_t(len([]byte(str))),
 		(*C.napi_value)(unsafe.Pointer(&result)),
 	))
+	debugResult(env, result)
+	return result, status
+}
+
+// CreateBufferFromArrayBuffer creates a Buffer that shares the memory of
+// arrayBuffer. It is only available on Node releases that export
+// node_api_create_buffer_from_arraybuffer.
+func CreateBufferFromArrayBuffer(env Env, arrayBuffer Value, byteOffset, length int) (Value, Status) {
+	debugCall(env, "CreateBufferFromArrayBuffer", arrayBuffer)
+	var result Value
+	status := Status(C.node_api_create_buffer_from_arraybuffer(
+		C.napi_env(env),
+		C.napi_value(arrayBuffer),
+		C.size_t(byteOffset),
+		C.size_t(length),
+		(*C.napi_value)(unsafe.Pointer(&result)),
+	))
+	debugResult(env, result)
 	return result, status
 }
+
+// PostFinalizer schedules finalize to run on the JS thread after the current
+// garbage collection, where it may call into JS. It is meant to be called
+// from finalizers that need more than basic access to env.
+func PostFinalizer(env Env, finalize Finalize, finalizeData, finalizeHint unsafe.Pointer) Status {
+	debugCall(env, "PostFinalizer")
+
+	finalizer, entry, status := createFinalizer(env, finalize, finalizeHint)
+	if status != StatusOK {
+		return status
+	}
+
+	status = Status(C.node_api_post_finalizer(
+		C.napi_env(env),
+		finalizer,
+		finalizeData,
+		finalizerHint(entry),
+	))
+
+	if status != StatusOK {
+		deleteFinalizer(env, entry)
+	}
+	return status
+}
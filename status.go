@@ -38,6 +38,8 @@ const (
 	StatusArraybufferExpected           Status = C.napi_arraybuffer_expected
 	StatusDetachableArraybufferExpected Status = C.napi_detachable_arraybuffer_expected
 	StatusWouldDeadlock                 Status = C.napi_would_deadlock
+	StatusNoExternalBuffersAllowed      Status = C.napi_no_external_buffers_allowed
+	StatusCannotRunJS                   Status = C.napi_cannot_run_js
 )
 
 func (s Status) String() string {
@@ -86,6 +88,10 @@ func (s Status) String() string {
 		return "napi_detachable_arraybuffer_expected"
 	case StatusWouldDeadlock:
 		return "napi_would_deadlock"
+	case StatusNoExternalBuffersAllowed:
+		return "napi_no_external_buffers_allowed"
+	case StatusCannotRunJS:
+		return "napi_cannot_run_js"
 	}
 
 	return "napi_go_status_unknown"
@@ -0,0 +1,30 @@
+package napi
+
+/*
+#cgo linux LDFLAGS: -ldl
+
+#define _GNU_SOURCE
+#include <stdlib.h>
+#include <dlfcn.h>
+
+static int napi_go_symbol_available(const char *name) {
+	return dlsym(RTLD_DEFAULT, name) != NULL;
+}
+*/
+import "C"
+
+import (
+	"unsafe"
+)
+
+// SymbolAvailable reports whether the Node-API function name, such as
+// "node_api_symbol_for", is exported by the running process. Addons are
+// linked without resolving Node-API symbols, so calling a function that the
+// running Node release lacks crashes the process; wrappers of newer and
+// experimental functions should check for them first.
+func SymbolAvailable(name string) bool {
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+
+	return C.napi_go_symbol_available(cname) != 0
+}
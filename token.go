@@ -0,0 +1,24 @@
+package napi
+
+/*
+#include <stdlib.h>
+*/
+import "C"
+
+import (
+	"unsafe"
+)
+
+// newToken copies id into C memory and returns a pointer to it, for use as
+// the data of napi callbacks. C must not retain pointers into Go memory, so
+// registry IDs and handles are passed this way rather than by address. The
+// token must be released with freeToken.
+func newToken[T ~int | ~uintptr](id T) unsafe.Pointer {
+	p := C.malloc(C.size_t(unsafe.Sizeof(id)))
+	*(*T)(p) = id
+	return p
+}
+
+func freeToken(p unsafe.Pointer) {
+	C.free(p)
+}
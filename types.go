@@ -19,14 +19,14 @@ type PropertyDescriptor struct {
 
 type Finalize func(env Env, finalizeData, finalizeHint unsafe.Pointer)
 
-func FinalizeToFinalizer(fn Finalize) Finalizer {
-	return func(env C.napi_env, finalizeData, finalizeHint unsafe.Pointer) {
-		fn(Env(env), finalizeData, finalizeHint)
-	}
+type CleanupHook struct {
+	ID NapiGoCleanupHookID
 }
 
-// Finalizer as a C-compatible function pointer type
-type Finalizer func(env C.napi_env, finalizeData, finalizeHint unsafe.Pointer)
+type TypeTag struct {
+	Lower uint64
+	Upper uint64
+}
 
 type Reference struct {
 	Ref unsafe.Pointer
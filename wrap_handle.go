@@ -0,0 +1,75 @@
+package napi
+
+/*
+#include <stdint.h>
+#include <node/node_api.h>
+
+extern void DeleteWrapHandle(uintptr_t handle);
+
+static void napi_go_finalize_wrap_handle(
+	node_api_basic_env env,
+	void *data,
+	void *hint
+) {
+	DeleteWrapHandle((uintptr_t)data);
+}
+
+static napi_status napi_go_wrap_handle(
+	napi_env env,
+	napi_value js_object,
+	uintptr_t handle
+) {
+	return napi_wrap(
+		env,
+		js_object,
+		(void *)handle,
+		napi_go_finalize_wrap_handle,
+		NULL,
+		NULL
+	);
+}
+
+static napi_status napi_go_unwrap_handle(
+	napi_env env,
+	napi_value js_object,
+	uintptr_t *result
+) {
+	void *data = NULL;
+	napi_status status = napi_unwrap(env, js_object, &data);
+	*result = (uintptr_t)data;
+	return status;
+}
+*/
+import "C"
+
+import (
+	"runtime/cgo"
+)
+
+// WrapHandle is like Wrap, but associates handle with jsObject rather than
+// a pointer. The handle is deleted once jsObject has been garbage collected,
+// so Go values can be attached to JS objects without passing Go pointers to
+// C.
+func WrapHandle(env Env, jsObject Value, handle cgo.Handle) Status {
+	debugCall(env, "WrapHandle", jsObject)
+
+	return Status(C.napi_go_wrap_handle(
+		C.napi_env(env),
+		C.napi_value(jsObject),
+		C.uintptr_t(handle),
+	))
+}
+
+// UnwrapHandle returns the handle associated with jsObject by WrapHandle.
+// The result is only meaningful for objects wrapped by WrapHandle, which
+// callers should verify, for example with CheckObjectTypeTag.
+func UnwrapHandle(env Env, jsObject Value) (cgo.Handle, Status) {
+	debugCall(env, "UnwrapHandle", jsObject)
+	var result C.uintptr_t
+	status := Status(C.napi_go_unwrap_handle(
+		C.napi_env(env),
+		C.napi_value(jsObject),
+		&result,
+	))
+	return cgo.Handle(result), status
+}
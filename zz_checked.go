@@ -0,0 +1,768 @@
+// Code generated by napigen. DO NOT EDIT.
+
+package napi
+
+import (
+	"runtime/cgo"
+	"unsafe"
+)
+
+func (c Checked) AddAsyncCleanupHook(hook func(AsyncCleanupHook)) (AsyncCleanupHook, error) {
+	r0, status := AddAsyncCleanupHook(c.Env, hook)
+	return r0, NewError(c.Env, "AddAsyncCleanupHook", status)
+}
+
+func (c Checked) AddEnvCleanupHook(hook func()) (CleanupHook, error) {
+	r0, status := AddEnvCleanupHook(c.Env, hook)
+	return r0, NewError(c.Env, "AddEnvCleanupHook", status)
+}
+
+func (c Checked) AddFinalizer(jsObject Value, finalizeData unsafe.Pointer, finalize Finalize, finalizeHint unsafe.Pointer) error {
+	status := AddFinalizer(c.Env, jsObject, finalizeData, finalize, finalizeHint)
+	return NewError(c.Env, "AddFinalizer", status)
+}
+
+func (c Checked) AdjustExternalMemory(change int64) (int64, error) {
+	r0, status := AdjustExternalMemory(c.Env, change)
+	return r0, NewError(c.Env, "AdjustExternalMemory", status)
+}
+
+func (c Checked) AsyncDestroy(asyncContext AsyncContext) error {
+	status := AsyncDestroy(c.Env, asyncContext)
+	return NewError(c.Env, "AsyncDestroy", status)
+}
+
+func (c Checked) AsyncInit(asyncResource, asyncResourceName Value) (AsyncContext, error) {
+	r0, status := AsyncInit(c.Env, asyncResource, asyncResourceName)
+	return r0, NewError(c.Env, "AsyncInit", status)
+}
+
+func (c Checked) CallFunction(recv Value, fn Value, argc int, argv []Value) (Value, error) {
+	r0, status := CallFunction(c.Env, recv, fn, argc, argv)
+	return r0, NewError(c.Env, "CallFunction", status)
+}
+
+func (c Checked) CancelAsyncWork(work AsyncWork) error {
+	status := CancelAsyncWork(c.Env, work)
+	return NewError(c.Env, "CancelAsyncWork", status)
+}
+
+func (c Checked) CheckObjectTypeTag(object Value, tag TypeTag) (bool, error) {
+	r0, status := CheckObjectTypeTag(c.Env, object, tag)
+	return r0, NewError(c.Env, "CheckObjectTypeTag", status)
+}
+
+func (c Checked) CloseCallbackScope(scope CallbackScope) error {
+	status := CloseCallbackScope(c.Env, scope)
+	return NewError(c.Env, "CloseCallbackScope", status)
+}
+
+func (c Checked) CloseEscapableHandleScope(scope EscapableHandleScope) error {
+	status := CloseEscapableHandleScope(c.Env, scope)
+	return NewError(c.Env, "CloseEscapableHandleScope", status)
+}
+
+func (c Checked) CloseHandleScope(scope HandleScope) error {
+	status := CloseHandleScope(c.Env, scope)
+	return NewError(c.Env, "CloseHandleScope", status)
+}
+
+func (c Checked) CoerceToBool(value Value) (Value, error) {
+	r0, status := CoerceToBool(c.Env, value)
+	return r0, NewError(c.Env, "CoerceToBool", status)
+}
+
+func (c Checked) CoerceToNumber(value Value) (Value, error) {
+	r0, status := CoerceToNumber(c.Env, value)
+	return r0, NewError(c.Env, "CoerceToNumber", status)
+}
+
+func (c Checked) CoerceToObject(value Value) (Value, error) {
+	r0, status := CoerceToObject(c.Env, value)
+	return r0, NewError(c.Env, "CoerceToObject", status)
+}
+
+func (c Checked) CoerceToString(value Value) (Value, error) {
+	r0, status := CoerceToString(c.Env, value)
+	return r0, NewError(c.Env, "CoerceToString", status)
+}
+
+func (c Checked) CreateArray() (Value, error) {
+	r0, status := CreateArray(c.Env)
+	return r0, NewError(c.Env, "CreateArray", status)
+}
+
+func (c Checked) CreateArrayBuffer(length int) (Value, *byte, error) {
+	r0, r1, status := CreateArrayBuffer(c.Env, length)
+	return r0, r1, NewError(c.Env, "CreateArrayBuffer", status)
+}
+
+func (c Checked) CreateArrayWithLength(length int) (Value, error) {
+	r0, status := CreateArrayWithLength(c.Env, length)
+	return r0, NewError(c.Env, "CreateArrayWithLength", status)
+}
+
+func (c Checked) CreateAsyncWork(asyncResource, asyncResourceName Value, execute AsyncExecuteCallback, complete AsyncCompleteCallback) (AsyncWork, error) {
+	r0, status := CreateAsyncWork(c.Env, asyncResource, asyncResourceName, execute, complete)
+	return r0, NewError(c.Env, "CreateAsyncWork", status)
+}
+
+func (c Checked) CreateBigIntInt64(value int64) (Value, error) {
+	r0, status := CreateBigIntInt64(c.Env, value)
+	return r0, NewError(c.Env, "CreateBigIntInt64", status)
+}
+
+func (c Checked) CreateBigIntUint64(value uint64) (Value, error) {
+	r0, status := CreateBigIntUint64(c.Env, value)
+	return r0, NewError(c.Env, "CreateBigIntUint64", status)
+}
+
+func (c Checked) CreateBigIntWords(signBit int, wordCount int, words *uint64) (Value, error) {
+	r0, status := CreateBigIntWords(c.Env, signBit, wordCount, words)
+	return r0, NewError(c.Env, "CreateBigIntWords", status)
+}
+
+func (c Checked) CreateBuffer(length int) (Value, []byte, error) {
+	r0, r1, status := CreateBuffer(c.Env, length)
+	return r0, r1, NewError(c.Env, "CreateBuffer", status)
+}
+
+func (c Checked) CreateBufferCopy(data []byte) (Value, *byte, error) {
+	r0, r1, status := CreateBufferCopy(c.Env, data)
+	return r0, r1, NewError(c.Env, "CreateBufferCopy", status)
+}
+
+func (c Checked) CreateBufferFromArrayBuffer(arrayBuffer Value, byteOffset, length int) (Value, error) {
+	r0, status := CreateBufferFromArrayBuffer(c.Env, arrayBuffer, byteOffset, length)
+	return r0, NewError(c.Env, "CreateBufferFromArrayBuffer", status)
+}
+
+func (c Checked) CreateDataView(length int, arrayBuffer Value, byteOffset int) (Value, error) {
+	r0, status := CreateDataView(c.Env, length, arrayBuffer, byteOffset)
+	return r0, NewError(c.Env, "CreateDataView", status)
+}
+
+func (c Checked) CreateDate(time float64) (Value, error) {
+	r0, status := CreateDate(c.Env, time)
+	return r0, NewError(c.Env, "CreateDate", status)
+}
+
+func (c Checked) CreateDouble(value float64) (Value, error) {
+	r0, status := CreateDouble(c.Env, value)
+	return r0, NewError(c.Env, "CreateDouble", status)
+}
+
+func (c Checked) CreateError(code, msg Value) (Value, error) {
+	r0, status := CreateError(c.Env, code, msg)
+	return r0, NewError(c.Env, "CreateError", status)
+}
+
+func (c Checked) CreateExternal(data unsafe.Pointer, finalize Finalize, finalizeHint unsafe.Pointer) (Value, error) {
+	r0, status := CreateExternal(c.Env, data, finalize, finalizeHint)
+	return r0, NewError(c.Env, "CreateExternal", status)
+}
+
+func (c Checked) CreateExternalArrayBuffer(data unsafe.Pointer, length int, finalize Finalize, finalizeHint unsafe.Pointer) (Value, error) {
+	r0, status := CreateExternalArrayBuffer(c.Env, data, length, finalize, finalizeHint)
+	return r0, NewError(c.Env, "CreateExternalArrayBuffer", status)
+}
+
+func (c Checked) CreateExternalBuffer(length int, data unsafe.Pointer, finalize Finalize, finalizeHint unsafe.Pointer) (Value, error) {
+	r0, status := CreateExternalBuffer(c.Env, length, data, finalize, finalizeHint)
+	return r0, NewError(c.Env, "CreateExternalBuffer", status)
+}
+
+func (c Checked) CreateExternalStringLatin1(str string, finalize Finalize, finalizeHint unsafe.Pointer) (Value, bool, error) {
+	r0, r1, status := CreateExternalStringLatin1(c.Env, str, finalize, finalizeHint)
+	return r0, r1, NewError(c.Env, "CreateExternalStringLatin1", status)
+}
+
+func (c Checked) CreateExternalStringUtf16(str []uint16, finalize Finalize, finalizeHint unsafe.Pointer) (Value, bool, error) {
+	r0, r1, status := CreateExternalStringUtf16(c.Env, str, finalize, finalizeHint)
+	return r0, r1, NewError(c.Env, "CreateExternalStringUtf16", status)
+}
+
+func (c Checked) CreateFunction(name string, cb Callback) (Value, error) {
+	r0, status := CreateFunction(c.Env, name, cb)
+	return r0, NewError(c.Env, "CreateFunction", status)
+}
+
+func (c Checked) CreateInt32(value int32) (Value, error) {
+	r0, status := CreateInt32(c.Env, value)
+	return r0, NewError(c.Env, "CreateInt32", status)
+}
+
+func (c Checked) CreateInt64(value int64) (Value, error) {
+	r0, status := CreateInt64(c.Env, value)
+	return r0, NewError(c.Env, "CreateInt64", status)
+}
+
+func (c Checked) CreateObject() (Value, error) {
+	r0, status := CreateObject(c.Env)
+	return r0, NewError(c.Env, "CreateObject", status)
+}
+
+func (c Checked) CreatePromise() (Promise, error) {
+	r0, status := CreatePromise(c.Env)
+	return r0, NewError(c.Env, "CreatePromise", status)
+}
+
+func (c Checked) CreatePropertyKeyLatin1(str string) (Value, error) {
+	r0, status := CreatePropertyKeyLatin1(c.Env, str)
+	return r0, NewError(c.Env, "CreatePropertyKeyLatin1", status)
+}
+
+func (c Checked) CreatePropertyKeyUtf16(str []uint16) (Value, error) {
+	r0, status := CreatePropertyKeyUtf16(c.Env, str)
+	return r0, NewError(c.Env, "CreatePropertyKeyUtf16", status)
+}
+
+func (c Checked) CreatePropertyKeyUtf8(str string) (Value, error) {
+	r0, status := CreatePropertyKeyUtf8(c.Env, str)
+	return r0, NewError(c.Env, "CreatePropertyKeyUtf8", status)
+}
+
+func (c Checked) CreateRangeError(code, msg Value) (Value, error) {
+	r0, status := CreateRangeError(c.Env, code, msg)
+	return r0, NewError(c.Env, "CreateRangeError", status)
+}
+
+func (c Checked) CreateReference(value Value, initialRefcount int) (Reference, error) {
+	r0, status := CreateReference(c.Env, value, initialRefcount)
+	return r0, NewError(c.Env, "CreateReference", status)
+}
+
+func (c Checked) CreateStringLatin1(str string) (Value, error) {
+	r0, status := CreateStringLatin1(c.Env, str)
+	return r0, NewError(c.Env, "CreateStringLatin1", status)
+}
+
+func (c Checked) CreateStringUtf16(str []uint16) (Value, error) {
+	r0, status := CreateStringUtf16(c.Env, str)
+	return r0, NewError(c.Env, "CreateStringUtf16", status)
+}
+
+func (c Checked) CreateStringUtf8(str string) (Value, error) {
+	r0, status := CreateStringUtf8(c.Env, str)
+	return r0, NewError(c.Env, "CreateStringUtf8", status)
+}
+
+func (c Checked) CreateSymbol(description Value) (Value, error) {
+	r0, status := CreateSymbol(c.Env, description)
+	return r0, NewError(c.Env, "CreateSymbol", status)
+}
+
+func (c Checked) CreateSyntaxError(code, msg Value) (Value, error) {
+	r0, status := CreateSyntaxError(c.Env, code, msg)
+	return r0, NewError(c.Env, "CreateSyntaxError", status)
+}
+
+func (c Checked) CreateThreadsafeFunction(fn Value, asyncResource, asyncResourceName Value, maxQueueSize, initialThreadCount int) (ThreadsafeFunction, error) {
+	r0, status := CreateThreadsafeFunction(c.Env, fn, asyncResource, asyncResourceName, maxQueueSize, initialThreadCount)
+	return r0, NewError(c.Env, "CreateThreadsafeFunction", status)
+}
+
+func (c Checked) CreateTypeError(code, msg Value) (Value, error) {
+	r0, status := CreateTypeError(c.Env, code, msg)
+	return r0, NewError(c.Env, "CreateTypeError", status)
+}
+
+func (c Checked) CreateTypedArray(type_ TypedArrayType, length int, arrayBuffer Value, byteOffset int) (Value, error) {
+	r0, status := CreateTypedArray(c.Env, type_, length, arrayBuffer, byteOffset)
+	return r0, NewError(c.Env, "CreateTypedArray", status)
+}
+
+func (c Checked) CreateUint32(value uint32) (Value, error) {
+	r0, status := CreateUint32(c.Env, value)
+	return r0, NewError(c.Env, "CreateUint32", status)
+}
+
+func (c Checked) DefineClass(name string, constructor Callback, properties []PropertyDescriptor) (Value, error) {
+	r0, status := DefineClass(c.Env, name, constructor, properties)
+	return r0, NewError(c.Env, "DefineClass", status)
+}
+
+func (c Checked) DefineProperties(object Value, properties []PropertyDescriptor) error {
+	status := DefineProperties(c.Env, object, properties)
+	return NewError(c.Env, "DefineProperties", status)
+}
+
+func (c Checked) DeleteAsyncWork(work AsyncWork) error {
+	status := DeleteAsyncWork(c.Env, work)
+	return NewError(c.Env, "DeleteAsyncWork", status)
+}
+
+func (c Checked) DeleteElement(object Value, index int) (bool, error) {
+	r0, status := DeleteElement(c.Env, object, index)
+	return r0, NewError(c.Env, "DeleteElement", status)
+}
+
+func (c Checked) DeleteProperty(object, key Value) (bool, error) {
+	r0, status := DeleteProperty(c.Env, object, key)
+	return r0, NewError(c.Env, "DeleteProperty", status)
+}
+
+func (c Checked) DeleteReference(ref Reference) error {
+	status := DeleteReference(c.Env, ref)
+	return NewError(c.Env, "DeleteReference", status)
+}
+
+func (c Checked) DetachArrayBuffer(value Value) error {
+	status := DetachArrayBuffer(c.Env, value)
+	return NewError(c.Env, "DetachArrayBuffer", status)
+}
+
+func (c Checked) EscapeHandle(scope EscapableHandleScope, escapee Value) (Value, error) {
+	r0, status := EscapeHandle(c.Env, scope, escapee)
+	return r0, NewError(c.Env, "EscapeHandle", status)
+}
+
+func (c Checked) FatalException(err Value) error {
+	status := FatalException(c.Env, err)
+	return NewError(c.Env, "FatalException", status)
+}
+
+func (c Checked) GetAllPropertyNames(object Value, keyMode KeyCollectionMode, keyFilter KeyFilter, keyConversion KeyConversion) (Value, error) {
+	r0, status := GetAllPropertyNames(c.Env, object, keyMode, keyFilter, keyConversion)
+	return r0, NewError(c.Env, "GetAllPropertyNames", status)
+}
+
+func (c Checked) GetAndClearLastException() (Value, error) {
+	r0, status := GetAndClearLastException(c.Env)
+	return r0, NewError(c.Env, "GetAndClearLastException", status)
+}
+
+func (c Checked) GetArrayBufferInfo(value Value) (*byte, int, error) {
+	r0, r1, status := GetArrayBufferInfo(c.Env, value)
+	return r0, r1, NewError(c.Env, "GetArrayBufferInfo", status)
+}
+
+func (c Checked) GetArrayLength(value Value) (int, error) {
+	r0, status := GetArrayLength(c.Env, value)
+	return r0, NewError(c.Env, "GetArrayLength", status)
+}
+
+func (c Checked) GetBoolean(value bool) (Value, error) {
+	r0, status := GetBoolean(c.Env, value)
+	return r0, NewError(c.Env, "GetBoolean", status)
+}
+
+func (c Checked) GetBufferInfo(value Value) (*byte, int, error) {
+	r0, r1, status := GetBufferInfo(c.Env, value)
+	return r0, r1, NewError(c.Env, "GetBufferInfo", status)
+}
+
+func (c Checked) GetCbInfo(info CallbackInfo) (GetCbInfoResult, error) {
+	r0, status := GetCbInfo(c.Env, info)
+	return r0, NewError(c.Env, "GetCbInfo", status)
+}
+
+func (c Checked) GetDataViewInfo(value Value) (int, *byte, Value, int, error) {
+	r0, r1, r2, r3, status := GetDataViewInfo(c.Env, value)
+	return r0, r1, r2, r3, NewError(c.Env, "GetDataViewInfo", status)
+}
+
+func (c Checked) GetDateValue(value Value) (float64, error) {
+	r0, status := GetDateValue(c.Env, value)
+	return r0, NewError(c.Env, "GetDateValue", status)
+}
+
+func (c Checked) GetElement(object Value, index int) (Value, error) {
+	r0, status := GetElement(c.Env, object, index)
+	return r0, NewError(c.Env, "GetElement", status)
+}
+
+func (c Checked) GetExtendedErrorInfo() (ExtendedErrorInfo, error) {
+	r0, status := GetExtendedErrorInfo(c.Env)
+	return r0, NewError(c.Env, "GetExtendedErrorInfo", status)
+}
+
+func (c Checked) GetGlobal() (Value, error) {
+	r0, status := GetGlobal(c.Env)
+	return r0, NewError(c.Env, "GetGlobal", status)
+}
+
+func (c Checked) GetInstanceData() (any, error) {
+	r0, status := GetInstanceData(c.Env)
+	return r0, NewError(c.Env, "GetInstanceData", status)
+}
+
+func (c Checked) GetModuleFileName() (string, error) {
+	r0, status := GetModuleFileName(c.Env)
+	return r0, NewError(c.Env, "GetModuleFileName", status)
+}
+
+func (c Checked) GetNamedProperty(object Value, name string) (Value, error) {
+	r0, status := GetNamedProperty(c.Env, object, name)
+	return r0, NewError(c.Env, "GetNamedProperty", status)
+}
+
+func (c Checked) GetNewTarget(info CallbackInfo) (Value, error) {
+	r0, status := GetNewTarget(c.Env, info)
+	return r0, NewError(c.Env, "GetNewTarget", status)
+}
+
+func (c Checked) GetNodeVersion() (NodeVersion, error) {
+	r0, status := GetNodeVersion(c.Env)
+	return r0, NewError(c.Env, "GetNodeVersion", status)
+}
+
+func (c Checked) GetNull() (Value, error) {
+	r0, status := GetNull(c.Env)
+	return r0, NewError(c.Env, "GetNull", status)
+}
+
+func (c Checked) GetProperty(object, key Value) (Value, error) {
+	r0, status := GetProperty(c.Env, object, key)
+	return r0, NewError(c.Env, "GetProperty", status)
+}
+
+func (c Checked) GetPropertyNames(object Value) (Value, error) {
+	r0, status := GetPropertyNames(c.Env, object)
+	return r0, NewError(c.Env, "GetPropertyNames", status)
+}
+
+func (c Checked) GetPrototype(value Value) (Value, error) {
+	r0, status := GetPrototype(c.Env, value)
+	return r0, NewError(c.Env, "GetPrototype", status)
+}
+
+func (c Checked) GetReferenceValue(ref Reference) (Value, error) {
+	r0, status := GetReferenceValue(c.Env, ref)
+	return r0, NewError(c.Env, "GetReferenceValue", status)
+}
+
+func (c Checked) GetTypedArrayInfo(value Value) (TypedArrayType, int, *byte, Value, int, error) {
+	r0, r1, r2, r3, r4, status := GetTypedArrayInfo(c.Env, value)
+	return r0, r1, r2, r3, r4, NewError(c.Env, "GetTypedArrayInfo", status)
+}
+
+func (c Checked) GetUVEventLoop() (unsafe.Pointer, error) {
+	r0, status := GetUVEventLoop(c.Env)
+	return r0, NewError(c.Env, "GetUVEventLoop", status)
+}
+
+func (c Checked) GetUndefined() (Value, error) {
+	r0, status := GetUndefined(c.Env)
+	return r0, NewError(c.Env, "GetUndefined", status)
+}
+
+func (c Checked) GetValueBigIntInt64(value Value) (int64, bool, error) {
+	r0, r1, status := GetValueBigIntInt64(c.Env, value)
+	return r0, r1, NewError(c.Env, "GetValueBigIntInt64", status)
+}
+
+func (c Checked) GetValueBigIntUint64(value Value) (uint64, bool, error) {
+	r0, r1, status := GetValueBigIntUint64(c.Env, value)
+	return r0, r1, NewError(c.Env, "GetValueBigIntUint64", status)
+}
+
+func (c Checked) GetValueBigIntWords(value Value, words []uint64) (int, int, error) {
+	r0, r1, status := GetValueBigIntWords(c.Env, value, words)
+	return r0, r1, NewError(c.Env, "GetValueBigIntWords", status)
+}
+
+func (c Checked) GetValueBool(value Value) (bool, error) {
+	r0, status := GetValueBool(c.Env, value)
+	return r0, NewError(c.Env, "GetValueBool", status)
+}
+
+func (c Checked) GetValueDouble(value Value) (float64, error) {
+	r0, status := GetValueDouble(c.Env, value)
+	return r0, NewError(c.Env, "GetValueDouble", status)
+}
+
+func (c Checked) GetValueExternal(value Value) (unsafe.Pointer, error) {
+	r0, status := GetValueExternal(c.Env, value)
+	return r0, NewError(c.Env, "GetValueExternal", status)
+}
+
+func (c Checked) GetValueInt32(value Value) (int32, error) {
+	r0, status := GetValueInt32(c.Env, value)
+	return r0, NewError(c.Env, "GetValueInt32", status)
+}
+
+func (c Checked) GetValueInt64(value Value) (int64, error) {
+	r0, status := GetValueInt64(c.Env, value)
+	return r0, NewError(c.Env, "GetValueInt64", status)
+}
+
+func (c Checked) GetValueStringLatin1(value Value) (string, error) {
+	r0, status := GetValueStringLatin1(c.Env, value)
+	return r0, NewError(c.Env, "GetValueStringLatin1", status)
+}
+
+func (c Checked) GetValueStringUtf16(value Value) ([]uint16, error) {
+	r0, status := GetValueStringUtf16(c.Env, value)
+	return r0, NewError(c.Env, "GetValueStringUtf16", status)
+}
+
+func (c Checked) GetValueStringUtf8(value Value) (string, error) {
+	r0, status := GetValueStringUtf8(c.Env, value)
+	return r0, NewError(c.Env, "GetValueStringUtf8", status)
+}
+
+func (c Checked) GetValueUint32(value Value) (uint32, error) {
+	r0, status := GetValueUint32(c.Env, value)
+	return r0, NewError(c.Env, "GetValueUint32", status)
+}
+
+func (c Checked) GetVersion() (uint32, error) {
+	r0, status := GetVersion(c.Env)
+	return r0, NewError(c.Env, "GetVersion", status)
+}
+
+func (c Checked) HasElement(object Value, index int) (bool, error) {
+	r0, status := HasElement(c.Env, object, index)
+	return r0, NewError(c.Env, "HasElement", status)
+}
+
+func (c Checked) HasNamedProperty(object Value, name string) (bool, error) {
+	r0, status := HasNamedProperty(c.Env, object, name)
+	return r0, NewError(c.Env, "HasNamedProperty", status)
+}
+
+func (c Checked) HasOwnProperty(object, key Value) (bool, error) {
+	r0, status := HasOwnProperty(c.Env, object, key)
+	return r0, NewError(c.Env, "HasOwnProperty", status)
+}
+
+func (c Checked) HasProperty(object, key Value) (bool, error) {
+	r0, status := HasProperty(c.Env, object, key)
+	return r0, NewError(c.Env, "HasProperty", status)
+}
+
+func (c Checked) InitializeInstanceData() error {
+	status := InitializeInstanceData(c.Env)
+	return NewError(c.Env, "InitializeInstanceData", status)
+}
+
+func (c Checked) InstanceOf(object, constructor Value) (bool, error) {
+	r0, status := InstanceOf(c.Env, object, constructor)
+	return r0, NewError(c.Env, "InstanceOf", status)
+}
+
+func (c Checked) IsArray(value Value) (bool, error) {
+	r0, status := IsArray(c.Env, value)
+	return r0, NewError(c.Env, "IsArray", status)
+}
+
+func (c Checked) IsArrayBuffer(value Value) (bool, error) {
+	r0, status := IsArrayBuffer(c.Env, value)
+	return r0, NewError(c.Env, "IsArrayBuffer", status)
+}
+
+func (c Checked) IsBuffer(value Value) (bool, error) {
+	r0, status := IsBuffer(c.Env, value)
+	return r0, NewError(c.Env, "IsBuffer", status)
+}
+
+func (c Checked) IsDataView(value Value) (bool, error) {
+	r0, status := IsDataView(c.Env, value)
+	return r0, NewError(c.Env, "IsDataView", status)
+}
+
+func (c Checked) IsDate(value Value) (bool, error) {
+	r0, status := IsDate(c.Env, value)
+	return r0, NewError(c.Env, "IsDate", status)
+}
+
+func (c Checked) IsDetachedArrayBuffer(value Value) (bool, error) {
+	r0, status := IsDetachedArrayBuffer(c.Env, value)
+	return r0, NewError(c.Env, "IsDetachedArrayBuffer", status)
+}
+
+func (c Checked) IsError(value Value) (bool, error) {
+	r0, status := IsError(c.Env, value)
+	return r0, NewError(c.Env, "IsError", status)
+}
+
+func (c Checked) IsExceptionPending() (bool, error) {
+	r0, status := IsExceptionPending(c.Env)
+	return r0, NewError(c.Env, "IsExceptionPending", status)
+}
+
+func (c Checked) IsPromise(value Value) (bool, error) {
+	r0, status := IsPromise(c.Env, value)
+	return r0, NewError(c.Env, "IsPromise", status)
+}
+
+func (c Checked) IsTypedArray(value Value) (bool, error) {
+	r0, status := IsTypedArray(c.Env, value)
+	return r0, NewError(c.Env, "IsTypedArray", status)
+}
+
+func (c Checked) MakeCallback(asyncContext AsyncContext, recv, fn Value, argc int, argv []Value) (Value, error) {
+	r0, status := MakeCallback(c.Env, asyncContext, recv, fn, argc, argv)
+	return r0, NewError(c.Env, "MakeCallback", status)
+}
+
+func (c Checked) NewInstance(constructor Value, argc int, argv []Value) (Value, error) {
+	r0, status := NewInstance(c.Env, constructor, argc, argv)
+	return r0, NewError(c.Env, "NewInstance", status)
+}
+
+func (c Checked) ObjectFreeze(object Value) error {
+	status := ObjectFreeze(c.Env, object)
+	return NewError(c.Env, "ObjectFreeze", status)
+}
+
+func (c Checked) ObjectSeal(object Value) error {
+	status := ObjectSeal(c.Env, object)
+	return NewError(c.Env, "ObjectSeal", status)
+}
+
+func (c Checked) OpenCallbackScope(resourceObject Value, asyncContext AsyncContext) (CallbackScope, error) {
+	r0, status := OpenCallbackScope(c.Env, resourceObject, asyncContext)
+	return r0, NewError(c.Env, "OpenCallbackScope", status)
+}
+
+func (c Checked) OpenEscapableHandleScope() (EscapableHandleScope, error) {
+	r0, status := OpenEscapableHandleScope(c.Env)
+	return r0, NewError(c.Env, "OpenEscapableHandleScope", status)
+}
+
+func (c Checked) OpenHandleScope() (HandleScope, error) {
+	r0, status := OpenHandleScope(c.Env)
+	return r0, NewError(c.Env, "OpenHandleScope", status)
+}
+
+func (c Checked) PostFinalizer(finalize Finalize, finalizeData, finalizeHint unsafe.Pointer) error {
+	status := PostFinalizer(c.Env, finalize, finalizeData, finalizeHint)
+	return NewError(c.Env, "PostFinalizer", status)
+}
+
+func (c Checked) QueueAsyncWork(work AsyncWork) error {
+	status := QueueAsyncWork(c.Env, work)
+	return NewError(c.Env, "QueueAsyncWork", status)
+}
+
+func (c Checked) RefThreadsafeFunction(fn ThreadsafeFunction) error {
+	status := RefThreadsafeFunction(c.Env, fn)
+	return NewError(c.Env, "RefThreadsafeFunction", status)
+}
+
+func (c Checked) ReferenceRef(ref Reference) (int, error) {
+	r0, status := ReferenceRef(c.Env, ref)
+	return r0, NewError(c.Env, "ReferenceRef", status)
+}
+
+func (c Checked) ReferenceUnref(ref Reference) (int, error) {
+	r0, status := ReferenceUnref(c.Env, ref)
+	return r0, NewError(c.Env, "ReferenceUnref", status)
+}
+
+func (c Checked) RejectDeferred(deferred Deferred, rejection Value) error {
+	status := RejectDeferred(c.Env, deferred, rejection)
+	return NewError(c.Env, "RejectDeferred", status)
+}
+
+func (c Checked) RemoveEnvCleanupHook(hook CleanupHook) error {
+	status := RemoveEnvCleanupHook(c.Env, hook)
+	return NewError(c.Env, "RemoveEnvCleanupHook", status)
+}
+
+func (c Checked) RemoveWrap(jsObject Value) error {
+	status := RemoveWrap(c.Env, jsObject)
+	return NewError(c.Env, "RemoveWrap", status)
+}
+
+func (c Checked) ResolveDeferred(deferred Deferred, resolution Value) error {
+	status := ResolveDeferred(c.Env, deferred, resolution)
+	return NewError(c.Env, "ResolveDeferred", status)
+}
+
+func (c Checked) RunScript(script Value) (Value, error) {
+	r0, status := RunScript(c.Env, script)
+	return r0, NewError(c.Env, "RunScript", status)
+}
+
+func (c Checked) SetElement(object Value, index int, value Value) error {
+	status := SetElement(c.Env, object, index, value)
+	return NewError(c.Env, "SetElement", status)
+}
+
+func (c Checked) SetInstanceData(data any) error {
+	status := SetInstanceData(c.Env, data)
+	return NewError(c.Env, "SetInstanceData", status)
+}
+
+func (c Checked) SetNamedProperty(object Value, name string, value Value) error {
+	status := SetNamedProperty(c.Env, object, name, value)
+	return NewError(c.Env, "SetNamedProperty", status)
+}
+
+func (c Checked) SetProperty(object, key, value Value) error {
+	status := SetProperty(c.Env, object, key, value)
+	return NewError(c.Env, "SetProperty", status)
+}
+
+func (c Checked) StrictEquals(lhs, rhs Value) (bool, error) {
+	r0, status := StrictEquals(c.Env, lhs, rhs)
+	return r0, NewError(c.Env, "StrictEquals", status)
+}
+
+func (c Checked) SymbolFor(description string) (Value, error) {
+	r0, status := SymbolFor(c.Env, description)
+	return r0, NewError(c.Env, "SymbolFor", status)
+}
+
+func (c Checked) Throw(err Value) error {
+	status := Throw(c.Env, err)
+	return NewError(c.Env, "Throw", status)
+}
+
+func (c Checked) ThrowError(code, msg string) error {
+	status := ThrowError(c.Env, code, msg)
+	return NewError(c.Env, "ThrowError", status)
+}
+
+func (c Checked) ThrowRangeError(code, msg string) error {
+	status := ThrowRangeError(c.Env, code, msg)
+	return NewError(c.Env, "ThrowRangeError", status)
+}
+
+func (c Checked) ThrowSyntaxError(code, msg string) error {
+	status := ThrowSyntaxError(c.Env, code, msg)
+	return NewError(c.Env, "ThrowSyntaxError", status)
+}
+
+func (c Checked) ThrowTypeError(code, msg string) error {
+	status := ThrowTypeError(c.Env, code, msg)
+	return NewError(c.Env, "ThrowTypeError", status)
+}
+
+func (c Checked) TypeTagObject(object Value, tag TypeTag) error {
+	status := TypeTagObject(c.Env, object, tag)
+	return NewError(c.Env, "TypeTagObject", status)
+}
+
+func (c Checked) Typeof(value Value) (ValueType, error) {
+	r0, status := Typeof(c.Env, value)
+	return r0, NewError(c.Env, "Typeof", status)
+}
+
+func (c Checked) UnrefThreadsafeFunction(fn ThreadsafeFunction) error {
+	status := UnrefThreadsafeFunction(c.Env, fn)
+	return NewError(c.Env, "UnrefThreadsafeFunction", status)
+}
+
+func (c Checked) Unwrap(jsObject Value) (unsafe.Pointer, error) {
+	r0, status := Unwrap(c.Env, jsObject)
+	return r0, NewError(c.Env, "Unwrap", status)
+}
+
+func (c Checked) UnwrapHandle(jsObject Value) (cgo.Handle, error) {
+	r0, status := UnwrapHandle(c.Env, jsObject)
+	return r0, NewError(c.Env, "UnwrapHandle", status)
+}
+
+func (c Checked) Wrap(jsObject Value, nativeObject unsafe.Pointer, finalize Finalize, finalizeHint unsafe.Pointer) error {
+	status := Wrap(c.Env, jsObject, nativeObject, finalize, finalizeHint)
+	return NewError(c.Env, "Wrap", status)
+}
+
+func (c Checked) WrapHandle(jsObject Value, handle cgo.Handle) error {
+	status := WrapHandle(c.Env, jsObject, handle)
+	return NewError(c.Env, "WrapHandle", status)
+}
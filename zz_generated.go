@@ -0,0 +1,67 @@
+// Code generated by napigen. DO NOT EDIT.
+
+package napi
+
+/*
+#include <node/node_api.h>
+*/
+import "C"
+
+import "unsafe"
+
+func IsArrayBuffer(env Env, value Value) (bool, Status) {
+	debugCall(env, "IsArrayBuffer", value)
+	var result C.bool
+	status := Status(C.napi_is_arraybuffer(
+		C.napi_env(env),
+		C.napi_value(value),
+		&result,
+	))
+	return bool(result), status
+}
+
+func IsDataView(env Env, value Value) (bool, Status) {
+	debugCall(env, "IsDataView", value)
+	var result C.bool
+	status := Status(C.napi_is_dataview(
+		C.napi_env(env),
+		C.napi_value(value),
+		&result,
+	))
+	return bool(result), status
+}
+
+func RunScript(env Env, script Value) (Value, Status) {
+	debugCall(env, "RunScript", script)
+	var result Value
+	status := Status(C.napi_run_script(
+		C.napi_env(env),
+		C.napi_value(script),
+		(*C.napi_value)(unsafe.Pointer(&result)),
+	))
+	debugResult(env, result)
+	return result, status
+}
+
+func CreateDate(env Env, time float64) (Value, Status) {
+	debugCall(env, "CreateDate")
+	var result Value
+	status := Status(C.napi_create_date(
+		C.napi_env(env),
+		C.double(time),
+		(*C.napi_value)(unsafe.Pointer(&result)),
+	))
+	debugResult(env, result)
+	return result, status
+}
+
+func GetDateValue(env Env, value Value) (float64, Status) {
+	debugCall(env, "GetDateValue", value)
+	var result C.double
+	status := Status(C.napi_get_date_value(
+		C.napi_env(env),
+		C.napi_value(value),
+		&result,
+	))
+	return float64(result), status
+}